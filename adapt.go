@@ -0,0 +1,19 @@
+package fixify
+
+// Provider is implemented by types outside fixify which stand for a model,
+// e.g. a proxy wrapping [Model] with its own methods, or a model constructed on demand.
+// Use [Adapt] to pass it to fixify.
+type Provider interface {
+	// FixifyModel returns the model which the provider stands for.
+	// It is called at most once, when fixify uses the provider for the first time.
+	FixifyModel() IModel
+}
+
+// Adapt returns IModel standing for the model provided by p.
+// Unlike [Lazy], the result can be passed anywhere IModel is accepted, including [Model.With],
+// because the model is provided as soon as it is used.
+// fixify connects, sorts and visits the provided model itself,
+// so that it is regarded as the same model whether it is passed directly or through p.
+func Adapt(p Provider) IModel {
+	return &lazyModel{f: p.FixifyModel, eager: true}
+}
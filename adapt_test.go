@@ -0,0 +1,126 @@
+package fixify_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// LibraryProxy is a proxy of a library model with its own methods.
+type LibraryProxy struct {
+	model *fixify.Model[model.Library]
+	// calls is the number of calls of FixifyModel.
+	calls int
+}
+
+func NewLibraryProxy() *LibraryProxy {
+	return &LibraryProxy{model: Library()}
+}
+
+// WithBooks registers n books as children of the library.
+func (p *LibraryProxy) WithBooks(n int) *LibraryProxy {
+	for range n {
+		p.model.With(Book())
+	}
+	return p
+}
+
+func (p *LibraryProxy) FixifyModel() fixify.IModel {
+	p.calls++
+	return p.model
+}
+
+func ExampleAdapt() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	library := NewLibraryProxy().WithBooks(2)
+	f := fixify.New(t,
+		fixify.Adapt(library),
+	)
+	fmt.Println(len(f.All()))
+	// Output:
+	// 3
+}
+
+func TestAdapt(t *testing.T) {
+	t.Parallel()
+	setter := func(v any) error {
+		switch v := v.(type) {
+		case *model.Library:
+			v.ID = 1
+		case *model.Book:
+			v.ID = 2
+		case *model.Page:
+			v.ID = 3
+		}
+		return nil
+	}
+
+	t.Run("child", func(t *testing.T) {
+		t.Parallel()
+		var book *fixify.Model[model.Book]
+		f := fixify.New(t,
+			Library().With(
+				fixify.Adapt(modelProvider(func() fixify.IModel { return Book().Bind(&book) })),
+			),
+		)
+		f.Apply(setter)
+		assert.Equal(t, &model.Book{ID: 2, LibraryID: 1}, book.Value())
+	})
+
+	t.Run("parent", func(t *testing.T) {
+		t.Parallel()
+		library := NewLibraryProxy()
+		page := Page()
+		f := fixify.New(t,
+			page.WithParent(Book().WithParent(fixify.Adapt(library))),
+		)
+		f.Apply(setter)
+		assert.Len(t, f.All(), 3)
+		assert.Equal(t, &model.Page{ID: 3, BookID: 2}, page.Value())
+	})
+
+	t.Run("same model passed directly and through the provider", func(t *testing.T) {
+		t.Parallel()
+		library := NewLibraryProxy()
+		adapted := fixify.Adapt(library)
+		f := fixify.New(t,
+			adapted,
+			library.model.With(Book()),
+			Book().WithParent(adapted),
+		)
+		assert.Len(t, f.All(), 3)
+		assert.Equal(t, 1, library.calls)
+	})
+
+	t.Run("resolved to nil", func(t *testing.T) {
+		t.Parallel()
+		assertInvalidModels(t, "adapted model is resolved to nil",
+			Library().With(fixify.Adapt(modelProvider(func() fixify.IModel { return nil }))),
+		)
+	})
+
+	t.Run("passed to New and resolved to nil", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.New(dt, fixify.Adapt(modelProvider(func() fixify.IModel { return nil })))
+		assert.Equal(t, []string{"invalid models: adapted model is resolved to nil"}, dt.messages)
+	})
+
+	t.Run("try to connect to non-parent", func(t *testing.T) {
+		t.Parallel()
+		assertInvalidModels(t, "cannot connect: child *model.Library -> parent *model.Book",
+			Book().With(fixify.Adapt(NewLibraryProxy())),
+		)
+	})
+}
+
+// modelProvider is a function providing a model.
+type modelProvider func() fixify.IModel
+
+func (f modelProvider) FixifyModel() fixify.IModel {
+	return f()
+}
@@ -0,0 +1,58 @@
+package fixify
+
+import (
+	"reflect"
+	"testing"
+)
+
+// connectAncestorFunc[U, V] implements Connecter[U].
+type connectAncestorFunc[U, V any] struct {
+	connectParentFunc[U, V]
+}
+
+var _ Connecter[int] = connectAncestorFunc[int, string]{}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f connectAncestorFunc[U, V]) canConnect(_ any, _ any) bool {
+	// an ancestor connector does not accept a parent directly.
+	return false
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f connectAncestorFunc[U, V]) kind() connectorKind {
+	return connectorKindAncestor
+}
+
+// AncestorConnector translates a function of the form func(t testing.TB, childModel *U, ancestorModel *V) into Connecter[U].
+// Unlike [ConnectorFunc], the function is called with the nearest ancestor of type *V instead of a parent,
+// so that a model can refer to its grandparent without an explicit edge.
+// [New] fails if there are multiple nearest ancestors of type *V.
+func AncestorConnector[U, V any](f func(t testing.TB, childModel *U, ancestorModel *V)) Connecter[U] {
+	return connectAncestorFunc[U, V]{connectParentFunc: ignoreError(f)}
+}
+
+// nearestAncestors returns the ancestors of type typ with the shortest distance from m.
+func nearestAncestors(m IModel, typ reflect.Type) []IModel {
+	visited := map[IModel]struct{}{m: {}}
+	current := []IModel{m}
+	for len(current) > 0 {
+		var next, found []IModel
+		for _, c := range current {
+			for _, p := range c.parents() {
+				if _, ok := visited[p]; ok {
+					continue
+				}
+				visited[p] = struct{}{}
+				if reflect.TypeOf(p.model()) == typ {
+					found = append(found, p)
+				}
+				next = append(next, p)
+			}
+		}
+		if len(found) > 0 {
+			return found
+		}
+		current = next
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package fixify_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleAncestorConnector() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	f := fixify.New(t,
+		Company().With(
+			Department("finance").With(
+				// the employee refers to the company through the department.
+				CompanyEmployee(),
+			),
+		),
+	)
+	f.Apply(func(v any) error {
+		switch v := v.(type) {
+		case *model.Company:
+			v.ID = 1
+		case *model.Department:
+			v.ID = 2
+		case *model.Employee:
+			v.ID = 3
+		}
+		return nil
+	})
+	for _, e := range filter[*model.Employee](f.All()) {
+		fmt.Printf("EmployeeID: %d DepartmentID: %d CompanyID: %d\n", e.ID, e.DepartmentID, e.CompanyID)
+	}
+	// Output:
+	// EmployeeID: 3 DepartmentID: 2 CompanyID: 1
+}
+
+func TestAncestorConnector(t *testing.T) {
+	t.Parallel()
+	t.Run("nearest ancestor", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.New(t,
+			Company().With(
+				Department("finance").With(
+					CompanyEmployee(),
+				),
+			),
+		)
+		f.Apply(func(v any) error {
+			if v, ok := v.(*model.Company); ok {
+				v.ID = 1
+			}
+			return nil
+		})
+		assert.Equal(t, []*model.Employee{{CompanyID: 1}}, filter[*model.Employee](f.All()))
+	})
+
+	t.Run("diamond", func(t *testing.T) {
+		t.Parallel()
+		var employee *fixify.Model[model.Employee]
+		dt := &dummyTestReporter{TB: t}
+		f := fixify.New(dt,
+			Company().With(
+				Department("finance").With(
+					CompanyEmployee().Bind(&employee),
+				),
+				// the same company is reached through the other department.
+				Department("sales").With(
+					employee,
+				),
+			),
+		)
+		assert.Equal(t, 0, dt.countFatalf)
+		assert.Len(t, f.All(), 4)
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		t.Parallel()
+		var employee *fixify.Model[model.Employee]
+		dt := &dummyTestReporter{TB: t}
+		f := fixify.New(dt,
+			Company().With(
+				Department("finance").With(
+					CompanyEmployee().Bind(&employee),
+				),
+			),
+			Company().With(
+				Department("sales").With(
+					employee,
+				),
+			),
+		)
+		assert.Empty(t, f.All())
+		assert.Equal(t, []string{"ambiguous ancestors: *model.Employee has 2 nearest ancestors of type *model.Company"}, dt.messages)
+	})
+
+	t.Run("no ancestor", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.New(t,
+			Department("finance").With(
+				CompanyEmployee(),
+			),
+		)
+		f.Apply(func(_ any) error { return nil })
+		assert.Equal(t, []*model.Employee{{}}, filter[*model.Employee](f.All()))
+	})
+
+	t.Run("no ancestor in strict mode", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.Config{Strict: true}.New(dt,
+			Department("finance").With(
+				CompanyEmployee(),
+			),
+		)
+		assert.Len(t, dt.messages, 1)
+		assert.Contains(t, dt.messages[0], "*model.Employee has no ancestor of type *model.Company")
+	})
+
+	t.Run("not a parent", func(t *testing.T) {
+		t.Parallel()
+		assertInvalidModels(t, "cannot connect: child *model.Employee -> parent *model.Company",
+			Company().With(CompanyEmployee()),
+		)
+	})
+}
+
+// CompanyEmployee represents a fixture for an employee referring to the company.
+func CompanyEmployee() *fixify.Model[model.Employee] {
+	return fixify.NewModel(new(model.Employee),
+		fixify.ConnectorFunc(func(_ testing.TB, employee *model.Employee, department *model.Department) {
+			employee.DepartmentID = department.ID
+		}),
+		fixify.AncestorConnector(func(_ testing.TB, employee *model.Employee, company *model.Company) {
+			employee.CompanyID = company.ID
+		}),
+	)
+}
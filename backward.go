@@ -0,0 +1,24 @@
+package fixify
+
+import "testing"
+
+// connectBackFunc[U, V] implements Connecter[U].
+type connectBackFunc[U, V any] struct {
+	connectParentFunc[U, V]
+}
+
+var _ Connecter[int] = connectBackFunc[int, string]{}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f connectBackFunc[U, V]) kind() connectorKind {
+	return connectorKindBackward
+}
+
+// BackConnectorFunc translates a function of the form func(t testing.TB, childModel *U, parentModel *V) into Connecter[U],
+// which updates the parent model instead of the child model.
+// Unlike [ConnectorFunc], the function is called after the child model is visited in [Fixture.Apply],
+// so that the parent can refer to the child, e.g. the ID of the child.
+// The updated parent model is visited again after all models are visited.
+func BackConnectorFunc[U, V any](f func(t testing.TB, childModel *U, parentModel *V)) Connecter[U] {
+	return connectBackFunc[U, V]{connectParentFunc: ignoreError(f)}
+}
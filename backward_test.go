@@ -0,0 +1,130 @@
+package fixify_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleBackConnectorFunc() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	f := fixify.New(t,
+		Department("finance").With(
+			Manager(),
+		),
+	)
+	f.Apply(func(v any) error {
+		switch v := v.(type) {
+		case *model.Department:
+			v.ID = 1
+		case *model.Employee:
+			v.ID = 2
+		}
+		return nil
+	})
+	for _, d := range filter[*model.Department](f.All()) {
+		fmt.Printf("DepartmentID: %d ManagerID: %d\n", d.ID, d.ManagerID)
+	}
+	// Output:
+	// DepartmentID: 1 ManagerID: 2
+}
+
+func TestBackConnectorFunc(t *testing.T) {
+	t.Parallel()
+	t.Run("parent is visited again", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.New(t,
+			Company().With(
+				Department("finance").With(
+					Manager(),
+					Employee(),
+				),
+			),
+		)
+		// saved records the departments as a visitor persisting models would see them.
+		var saved []model.Department
+		var id int64
+		f.Apply(func(v any) error {
+			switch v := v.(type) {
+			case *model.Company:
+				v.ID = 1
+			case *model.Department:
+				if v.ID == 0 {
+					v.ID = 2
+				}
+				saved = append(saved, *v)
+			case *model.Employee:
+				id++
+				v.ID = 10 + id
+			}
+			return nil
+		})
+		managers := filter[*model.Employee](f.All())
+		assert.Len(t, managers, 2)
+		d := filter[*model.Department](f.All())[0]
+		assert.NotZero(t, d.ManagerID)
+		assert.Equal(t, []model.Department{
+			{ID: 2, CompanyID: 1, Name: "finance"},
+			{ID: 2, CompanyID: 1, Name: "finance", ManagerID: d.ManagerID},
+		}, saved)
+	})
+
+	t.Run("not visited again without backward connectors", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.New(t,
+			Department("finance").With(
+				Employee(),
+			),
+		)
+		count := 0
+		f.Apply(func(v any) error {
+			if _, ok := v.(*model.Department); ok {
+				count++
+			}
+			return nil
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		f := fixify.New(dt,
+			Department("finance").With(
+				Manager(),
+			),
+		)
+		f.Apply(func(v any) error {
+			if _, ok := v.(*model.Department); ok {
+				return errors.New("error")
+			}
+			return nil
+		})
+		// the department is visited twice.
+		assert.Equal(t, 2, dt.countFatalf)
+	})
+
+	t.Run("factories create one parent for both kinds of connectors", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.Config{Factories: defaultFactories}.New(t, Manager())
+		assert.Len(t, filter[*model.Department](f.All()), 1)
+	})
+}
+
+// Manager represents a fixture for an employee managing the department.
+func Manager() *fixify.Model[model.Employee] {
+	return fixify.NewModel(new(model.Employee),
+		fixify.ConnectorFunc(func(_ testing.TB, employee *model.Employee, department *model.Department) {
+			employee.DepartmentID = department.ID
+		}),
+		// the department refers to the manager.
+		fixify.BackConnectorFunc(func(_ testing.TB, employee *model.Employee, department *model.Department) {
+			department.ManagerID = employee.ID
+		}),
+	)
+}
@@ -0,0 +1,210 @@
+// Fixifygen generates fixture constructors of fixify from model structs.
+//
+// For each exported struct in the model package, it generates a constructor returning *fixify.Model
+// with a connector for each field named XxxID, which refers to the field ID of the struct Xxx.
+// Fields whose names do not follow the convention can be mapped to their parents with -ref.
+// If a model has multiple fields referring to the same parent, the connectors are labeled after the fields.
+//
+// With -schema, the foreign keys are read from CREATE TABLE statements in the SQL files instead,
+// and the connectors are labeled after the columns if a table has multiple foreign keys to the same table.
+// The structs for the tables which are not declared in the model package are generated into the file given by -model-o.
+//
+// Usage:
+//
+//	//go:generate go run github.com/qawatake/fixify/cmd/fixifygen -dir ../model -o fixture_gen.go -ref Follow.FollowerID=User
+//	//go:generate go run github.com/qawatake/fixify/cmd/fixifygen -schema ../../db/schema.sql -dir ../model -o fixture_gen.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/qawatake/fixify/internal/gen"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "fixifygen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// refs is a flag which maps Struct.Field to the struct it refers to.
+type refs map[string]string
+
+func (r refs) String() string {
+	s := make([]string, 0, len(r))
+	for k, v := range r {
+		s = append(s, k+"="+v)
+	}
+	return strings.Join(s, ",")
+}
+
+func (r refs) Set(v string) error {
+	field, parent, ok := strings.Cut(v, "=")
+	if !ok {
+		return fmt.Errorf("invalid reference %q: want Struct.Field=Parent", v)
+	}
+	r[field] = parent
+	return nil
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("fixifygen", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "directory of the model package")
+	importPath := fs.String("import", "", "import path of the model package (default: resolved by go list)")
+	pkg := fs.String("pkg", "", "package name of the generated file (default: the name of the output directory)")
+	out := fs.String("o", "", "output file (default: stdout)")
+	r := make(refs)
+	fs.Var(r, "ref", "reference of a field to a struct in the form of Struct.Field=Parent (repeatable)")
+	schema := fs.String("schema", "", "SQL file or directory of SQL files declaring the tables")
+	modelOut := fs.String("model-o", "", "output file of the structs for the tables with -schema (default: model_gen.go in -dir)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var modelPkg string
+	var models []gen.Model
+	var err error
+	if *schema != "" {
+		if len(r) > 0 {
+			return errors.New("-ref cannot be used with -schema")
+		}
+		if *modelOut == "" {
+			*modelOut = filepath.Join(*dir, "model_gen.go")
+		}
+		modelPkg, models, err = fromSchema(*schema, *dir, *modelOut)
+	} else {
+		modelPkg, models, err = fromStructs(*dir, r)
+	}
+	if err != nil {
+		return err
+	}
+	if *importPath == "" {
+		if *importPath, err = goList(*dir); err != nil {
+			return err
+		}
+	}
+	if *pkg == "" {
+		if *pkg, err = outputPackage(*out); err != nil {
+			return err
+		}
+	}
+	src, err := gen.Generate(gen.File{
+		Package:      *pkg,
+		ModelImport:  *importPath,
+		ModelPackage: modelPkg,
+		Models:       models,
+	})
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err := os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*out, src, 0o644)
+}
+
+// fromStructs returns the models inferred from the structs in dir.
+func fromStructs(dir string, r refs) (string, []gen.Model, error) {
+	pkg, structs, err := gen.ParseDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	models, err := gen.Infer(structs, r)
+	if err != nil {
+		return "", nil, err
+	}
+	return pkg, models, nil
+}
+
+// fromSchema returns the models for the tables in the schema,
+// writing the structs which are not declared in dir to modelOut.
+func fromSchema(schema, dir, modelOut string) (string, []gen.Model, error) {
+	tables, err := parseSchema(schema)
+	if err != nil {
+		return "", nil, err
+	}
+	pkg, structs, err := gen.ParseDir(dir)
+	if errors.Is(err, gen.ErrNoGoFiles) {
+		if pkg, err = outputPackage(modelOut); err != nil {
+			return "", nil, err
+		}
+	} else if err != nil {
+		return "", nil, err
+	}
+	// the structs in modelOut are generated again.
+	existing := slices.DeleteFunc(structs, func(s gen.Struct) bool {
+		return s.File == filepath.Base(modelOut)
+	})
+	declared, models, err := gen.FromTables(tables, existing)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(declared) == 0 {
+		if err := os.Remove(modelOut); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", nil, err
+		}
+		return pkg, models, nil
+	}
+	src, err := gen.GenerateStructs(pkg, declared)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := os.WriteFile(modelOut, src, 0o644); err != nil {
+		return "", nil, err
+	}
+	return pkg, models, nil
+}
+
+// parseSchema parses the SQL file or the SQL files in the directory in the lexical order.
+func parseSchema(path string) ([]gen.Table, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.sql")); err != nil {
+			return nil, err
+		}
+	}
+	var tables []gen.Table
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if tables, err = gen.ParseDDL(string(b), tables); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+	}
+	return tables, nil
+}
+
+// goList returns the import path of the package in dir.
+func goList(dir string) (string, error) {
+	cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}", ".")
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+	b, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the import path of %s: %w", dir, err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// outputPackage returns the package name for the output file, which is the name of its directory.
+func outputPackage(out string) (string, error) {
+	dir, err := filepath.Abs(filepath.Dir(out))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(dir), nil
+}
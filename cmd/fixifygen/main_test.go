@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	t.Run("output", func(t *testing.T) {
+		t.Parallel()
+		out := filepath.Join(t.TempDir(), "fixture", "fixture_gen.go")
+		require.NoError(t, os.Mkdir(filepath.Dir(out), 0o755))
+		err := run([]string{
+			"-dir", "../../internal/example/model",
+			"-import", "github.com/qawatake/fixify/internal/example/model",
+			"-o", out,
+			"-ref", "Follow.FollowerID=User",
+			"-ref", "Follow.FolloweeID=User",
+			"-ref", "Ticket.AssigneeID=User",
+			"-ref", "Ticket.ReviewerID=User",
+		})
+		require.NoError(t, err)
+		got, err := os.ReadFile(out)
+		require.NoError(t, err)
+		want, err := os.ReadFile("../../internal/example/fixture/fixture_gen.go")
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got))
+	})
+
+	t.Run("invalid reference", func(t *testing.T) {
+		t.Parallel()
+		err := run([]string{"-dir", "../../internal/example/model", "-ref", "Follow.FollowerID"})
+		assert.Error(t, err)
+	})
+}
+
+func TestRun_schema(t *testing.T) {
+	t.Parallel()
+	t.Run("up to date", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		modelDir := filepath.Join(dir, "model")
+		require.NoError(t, os.Mkdir(modelDir, 0o755))
+		user, err := os.ReadFile("../../internal/example/schema/model/user.go")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(modelDir, "user.go"), user, 0o600))
+		out := filepath.Join(dir, "fixture", "fixture_gen.go")
+		require.NoError(t, os.Mkdir(filepath.Dir(out), 0o755))
+		args := []string{
+			"-schema", "../../internal/example/schema/schema.sql",
+			"-dir", modelDir,
+			"-import", "github.com/qawatake/fixify/internal/example/schema/model",
+			"-o", out,
+		}
+		// the second run generates the same files from the structs generated by the first run.
+		for range 2 {
+			require.NoError(t, run(args))
+			assertSameFile(t, "../../internal/example/schema/model/model_gen.go", filepath.Join(modelDir, "model_gen.go"))
+			assertSameFile(t, "../../internal/example/schema/fixture/fixture_gen.go", out)
+		}
+	})
+
+	t.Run("schema directory without model package", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		schema := filepath.Join(dir, "schema")
+		modelDir := filepath.Join(dir, "entity")
+		require.NoError(t, os.Mkdir(schema, 0o755))
+		require.NoError(t, os.Mkdir(modelDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(schema, "001_users.sql"), []byte("CREATE TABLE users (id BIGINT PRIMARY KEY, inviter_id BIGINT);"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(schema, "002_fk.sql"), []byte("ALTER TABLE users ADD FOREIGN KEY (inviter_id) REFERENCES users (id);"), 0o600))
+		out := filepath.Join(dir, "fixture_gen.go")
+		err := run([]string{"-schema", schema, "-dir", modelDir, "-import", "example.com/entity", "-pkg", "fixture", "-o", out})
+		require.NoError(t, err)
+		src, err := os.ReadFile(filepath.Join(modelDir, "model_gen.go"))
+		require.NoError(t, err)
+		assert.Contains(t, string(src), "package entity\n")
+		src, err = os.ReadFile(out)
+		require.NoError(t, err)
+		assert.Contains(t, string(src), "user.InviterID = sql.NullInt64{Int64: parent.ID, Valid: true}")
+	})
+
+	t.Run("ref with schema", func(t *testing.T) {
+		t.Parallel()
+		err := run([]string{"-schema", "../../internal/example/schema/schema.sql", "-ref", "Follow.FollowerID=User"})
+		assert.Error(t, err)
+	})
+}
+
+func assertSameFile(t *testing.T, want, got string) {
+	t.Helper()
+	w, err := os.ReadFile(want)
+	require.NoError(t, err)
+	g, err := os.ReadFile(got)
+	require.NoError(t, err)
+	assert.Equal(t, string(w), string(g))
+}
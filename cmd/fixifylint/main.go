@@ -0,0 +1,13 @@
+// Fixifylint reports misuses of fixify.
+//
+// Usage:
+//
+//	go vet -vettool=$(which fixifylint) ./...
+package main
+
+import (
+	"github.com/qawatake/fixify/fixifylint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() { singlechecker.Main(fixifylint.Analyzer) }
@@ -0,0 +1,96 @@
+package fixify
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Add adds the models and the models connected to them to the fixture.
+// The added models may have models in the fixture as parents, but not as children.
+// They are sorted in a topological order and placed after the models in the fixture,
+// so that the next [Fixture.Apply] visits only them.
+func (f *Fixture) Add(models ...IModel) *Fixture {
+	f.t.Helper()
+	f.add(models)
+	return f
+}
+
+// Merge returns a new fixture with the models in f and others.
+// The models are placed in the order of the fixtures as long as every parent comes before its children,
+// and models in multiple fixtures are placed only once.
+// The new fixture uses testing.TB, [Config] and [Hooks] of f,
+// and a model visited by [Fixture.Apply] of any fixture is not visited again.
+// A model which is not visited yet cannot be a parent of a visited model,
+// because the connectors of the child have already been called.
+func Merge(f *Fixture, others ...*Fixture) *Fixture {
+	f.t.Helper()
+	merged := &Fixture{
+		t:       f.t,
+		cfg:     f.cfg,
+		set:     map[IModel]struct{}{},
+		visited: map[IModel]struct{}{},
+		hooks:   slices.Clone(f.hooks),
+	}
+	for _, ff := range append([]*Fixture{f}, others...) {
+		for _, c := range ff.connectors {
+			if _, ok := merged.set[c]; ok {
+				continue
+			}
+			merged.connectors = append(merged.connectors, c)
+			merged.set[c] = struct{}{}
+		}
+		for c := range ff.visited {
+			merged.visited[c] = struct{}{}
+		}
+	}
+	var errs []error
+	for _, c := range merged.connectors {
+		if _, ok := merged.visited[c]; ok {
+			continue
+		}
+		for _, child := range c.children() {
+			if _, ok := merged.visited[child]; ok {
+				errs = append(errs, fmt.Errorf("cannot merge a parent not visited with a visited child: child %T -> parent %T", child.model(), c.model()))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		f.t.Fatalf("invalid models: %v", errors.Join(errs...))
+		return merged
+	}
+	// a parent in a later fixture is placed before its children in an earlier fixture.
+	merged.connectors = sortModelsInOrder(merged.connectors)
+	return merged
+}
+
+// Dirty marks the models and their descendants as not visited,
+// so that the next [Fixture.Apply] visits them and calls their connectors again.
+// Models not in the fixture are ignored.
+func (f *Fixture) Dirty(models ...IModel) {
+	var mark func(m IModel)
+	mark = func(m IModel) {
+		if l, ok := m.(*lazyModel); ok {
+			if l.resolved == nil {
+				// it is not used in the fixture yet.
+				return
+			}
+			m = l.target()
+		}
+		if _, ok := f.visited[m]; !ok {
+			return
+		}
+		delete(f.visited, m)
+		for _, c := range m.children() {
+			mark(c)
+		}
+	}
+	for _, m := range models {
+		mark(m)
+	}
+}
+
+// Reset marks all models as not visited, so that the next [Fixture.Apply] visits all models again.
+func (f *Fixture) Reset() {
+	clear(f.visited)
+}
@@ -0,0 +1,235 @@
+package fixify_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleFixture_Add() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	var library *fixify.Model[model.Library]
+	// shared setup, e.g. in a helper function.
+	f := fixify.New(t,
+		Library().Bind(&library),
+	)
+	f.Apply(func(v any) error {
+		fmt.Printf("visit %T\n", v)
+		return nil
+	})
+	// each test adds its own models.
+	f.Add(
+		Book().WithParent(library),
+	)
+	f.Apply(func(v any) error {
+		fmt.Printf("visit %T\n", v)
+		return nil
+	})
+	// Output:
+	// visit *model.Library
+	// visit *model.Book
+}
+
+func TestFixture_Add(t *testing.T) {
+	t.Parallel()
+	t.Run("child of applied model", func(t *testing.T) {
+		t.Parallel()
+		var library *fixify.Model[model.Library]
+		f := fixify.New(t,
+			Library().Bind(&library),
+		)
+		var visited []any
+		setter := func(v any) error {
+			visited = append(visited, v)
+			switch v := v.(type) {
+			case *model.Library:
+				v.ID = 1
+			case *model.Book:
+				v.ID = 2
+			case *model.Page:
+				v.ID = 3
+			}
+			return nil
+		}
+		f.Apply(setter)
+		f.Add(
+			Book().WithParent(library).With(
+				Page(),
+			),
+		)
+		f.Apply(setter)
+		assert.Len(t, visited, 3)
+		assert.Len(t, f.All(), 3)
+		assert.Equal(t, []*model.Book{{ID: 2, LibraryID: 1}}, filter[*model.Book](f.All()))
+		assert.Equal(t, []*model.Page{{ID: 3, BookID: 2}}, filter[*model.Page](f.All()))
+	})
+
+	t.Run("added before apply", func(t *testing.T) {
+		t.Parallel()
+		var library *fixify.Model[model.Library]
+		f := fixify.New(t,
+			Library().Bind(&library),
+		)
+		f.Add(Book().WithParent(library), Book().WithParent(library))
+		count := 0
+		f.Apply(func(_ any) error {
+			count++
+			return nil
+		})
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("parent of model in fixture", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		var book *fixify.Model[model.Book]
+		f := fixify.New(dt,
+			Book().Bind(&book),
+		)
+		f.Add(Library().With(book))
+		assert.Equal(t, []string{"invalid models: cannot add a parent of a model in the fixture: child *model.Book -> parent *model.Library"}, dt.messages)
+		assert.Len(t, f.All(), 1)
+	})
+
+	t.Run("factories of the fixture", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.Config{Factories: defaultFactories}.New(t)
+		f.Add(Employee())
+		assert.Len(t, f.All(), 3)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	var library *fixify.Model[model.Library]
+	f1 := fixify.New(t,
+		Library().Bind(&library),
+	)
+	var visited []any
+	visit := func(v any) error {
+		visited = append(visited, v)
+		return nil
+	}
+	f1.Apply(visit)
+	f2 := fixify.New(t,
+		Book().WithParent(library),
+	)
+	f := fixify.Merge(f1, f2)
+	assert.Len(t, f.All(), 2)
+	f.Apply(visit)
+	assert.Len(t, visited, 2)
+	assert.IsType(t, &model.Book{}, visited[1])
+}
+
+func TestMerge_parent_in_later_fixture(t *testing.T) {
+	t.Parallel()
+	book := Book()
+	f1 := fixify.New(t, book)
+	library := Library().With(book)
+	f2 := fixify.New(t, library)
+	f := fixify.Merge(f1, f2)
+	all := f.All()
+	if assert.Len(t, all, 2) {
+		assert.Same(t, library.Value(), all[0])
+		assert.Same(t, book.Value(), all[1])
+	}
+	f.Apply(func(v any) error {
+		switch v := v.(type) {
+		case *model.Library:
+			v.ID = 1
+		case *model.Book:
+			v.ID = 2
+		}
+		return nil
+	})
+	assert.Equal(t, int64(1), book.Value().LibraryID)
+}
+
+func TestMerge_parent_of_visited_child(t *testing.T) {
+	t.Parallel()
+	dt := &dummyTestReporter{TB: t}
+	book := Book()
+	f1 := fixify.New(dt, book)
+	f1.Apply(func(v any) error { return nil })
+	f2 := fixify.New(dt, Library().With(book))
+	fixify.Merge(f1, f2)
+	assert.Equal(t, []string{"invalid models: cannot merge a parent not visited with a visited child: child *model.Book -> parent *model.Library"}, dt.messages)
+}
+
+func TestFixture_Apply_incremental(t *testing.T) {
+	t.Parallel()
+	// newFixture returns a fixture and a function returning the models visited since the last call.
+	newFixture := func(t *testing.T, models ...fixify.IModel) (*fixify.Fixture, func() []any) {
+		t.Helper()
+		f := fixify.New(t, models...)
+		var visited []any
+		var id int64
+		return f, func() []any {
+			visited = nil
+			f.Apply(func(v any) error {
+				visited = append(visited, v)
+				id++
+				switch v := v.(type) {
+				case *model.Library:
+					v.ID = id
+				case *model.Book:
+					v.ID = id
+				case *model.Page:
+					v.ID = id
+				}
+				return nil
+			})
+			return visited
+		}
+	}
+
+	t.Run("twice", func(t *testing.T) {
+		t.Parallel()
+		_, apply := newFixture(t, Library().With(Book()))
+		assert.Len(t, apply(), 2)
+		assert.Empty(t, apply())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		t.Parallel()
+		f, apply := newFixture(t, Library().With(Book()))
+		assert.Len(t, apply(), 2)
+		f.Reset()
+		assert.Len(t, apply(), 2)
+		// connectors are called again.
+		books := filter[*model.Book](f.All())
+		libraries := filter[*model.Library](f.All())
+		assert.Equal(t, libraries[0].ID, books[0].LibraryID)
+	})
+
+	t.Run("dirty", func(t *testing.T) {
+		t.Parallel()
+		var book *fixify.Model[model.Book]
+		f, apply := newFixture(t,
+			Library().With(
+				Book().Bind(&book).With(
+					Page(),
+				),
+				Book(),
+			),
+		)
+		assert.Len(t, apply(), 4)
+		f.Dirty(book)
+		visited := apply()
+		assert.ElementsMatch(t, []any{book.Value(), filter[*model.Page](f.All())[0]}, visited)
+		// the page is connected to the book again.
+		assert.Equal(t, book.Value().ID, filter[*model.Page](f.All())[0].BookID)
+	})
+
+	t.Run("dirty model not in fixture", func(t *testing.T) {
+		t.Parallel()
+		f, apply := newFixture(t, Library())
+		assert.Len(t, apply(), 1)
+		f.Dirty(Library())
+		assert.Empty(t, apply())
+	})
+}
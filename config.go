@@ -0,0 +1,27 @@
+package fixify
+
+// Config configures how [Config.New] builds a fixture.
+// The zero value behaves the same as [New].
+type Config struct {
+	// Strict enables the strict mode.
+	// In the strict mode, every connector not marked as [Optional] is regarded as [Required],
+	// so that a model without a parent for one of its connectors is reported as an error.
+	// Ambiguous connectors and duplicate edges, which are reported as warnings by default, are also reported as errors.
+	Strict bool
+	// Factories enables [Config.New] to create missing parents.
+	// For every connector not marked as [Optional] that has no parent,
+	// a parent is created by the factory for the parent type and connected with the label of the connector.
+	// Parents of the created models are created recursively, and all of them are included in the fixture.
+	// If multiple factories are given for the same type, the last one is used,
+	// so that a test can override shared factories by appending its own.
+	Factories []Factory
+	// Deterministic sorts the models in their declaration order instead of a random order,
+	// as long as every parent comes before its children.
+	// The declaration order is the order in which the models appear in the arguments of [Config.New],
+	// followed by their children in the order of the arguments of [Model.With] and their parents in the order they are registered.
+	// It makes the order of [Fixture.Apply] predictable, e.g. for IDs assigned by a database.
+	Deterministic bool
+	// Panic makes [Config.New] panic with the errors occurred in constructing models,
+	// e.g. in [Model.With] and [Model.WithParentAs], instead of reporting them with tb.Fatalf.
+	Panic bool
+}
@@ -0,0 +1,126 @@
+package fixify_test
+
+import (
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleConfig_New() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	fixify.Config{Strict: true}.New(t,
+		Company().With(
+			Department("finance").With(
+				Employee(),
+			),
+		),
+	)
+	// Output:
+}
+
+func TestConfig_New_strict(t *testing.T) {
+	t.Parallel()
+	t.Run("orphan", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		f := fixify.Config{Strict: true}.New(dt, Employee())
+		assert.Empty(t, f.All())
+		assert.Equal(t, []string{"missing parents: *model.Employee has no parent of type *model.Department"}, dt.messages)
+	})
+
+	t.Run("orphan in non-strict mode", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.Config{}.New(dt, Employee())
+		assert.Equal(t, 0, dt.countFatalf)
+	})
+
+	t.Run("connected", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.Config{Strict: true}.New(dt,
+			Company().With(
+				Department("finance").With(
+					Employee(),
+				),
+			),
+		)
+		assert.Equal(t, 0, dt.countFatalf)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.Config{Strict: true}.New(dt, Ticket())
+		assert.Equal(t, 0, dt.countFatalf)
+	})
+
+	t.Run("one of two parents", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.Config{Strict: true}.New(dt,
+			Student().With(
+				Enrollment(),
+			),
+		)
+		assert.Equal(t, []string{"missing parents: *model.Enrollment has no parent of type *model.Classroom"}, dt.messages)
+	})
+}
+
+func TestConfig_New_deterministic(t *testing.T) {
+	t.Parallel()
+	t.Run("declaration order", func(t *testing.T) {
+		t.Parallel()
+		for range 20 {
+			company := Company()
+			a, b := Department("a"), Department("b")
+			e1, e2, e3 := Employee(), Employee(), Employee()
+			f := fixify.Config{Deterministic: true}.New(t,
+				company.With(
+					a.With(e1, e2),
+					b.With(e3),
+				),
+			)
+			assertSameModels(t, []any{company.Value(), a.Value(), e1.Value(), e2.Value(), b.Value(), e3.Value()}, f.All())
+		}
+	})
+
+	t.Run("child declared before parent", func(t *testing.T) {
+		t.Parallel()
+		for range 20 {
+			follow := Follow()
+			follower, followee := User("follower"), User("followee")
+			f := fixify.Config{Deterministic: true}.New(t,
+				follow.
+					WithParentAs("follower", follower).
+					WithParentAs("followee", followee),
+			)
+			assertSameModels(t, []any{follower.Value(), followee.Value(), follow.Value()}, f.All())
+		}
+	})
+
+	t.Run("cyclic dependency", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		a, b, c := Cyclic(), Cyclic(), Cyclic()
+		a.With(b)
+		b.With(c)
+		c.With(a)
+		f := fixify.Config{Deterministic: true}.New(dt, a, Company())
+		assert.Empty(t, f.All())
+		assert.Equal(t, []string{"cyclic dependency: cannot sort *model.Cyclic, *model.Cyclic, *model.Cyclic"}, dt.messages)
+	})
+}
+
+// assertSameModels asserts that actual has the same pointers as expected in the same order.
+func assertSameModels(t *testing.T, expected, actual []any) {
+	t.Helper()
+	if !assert.Len(t, actual, len(expected)) {
+		return
+	}
+	for i := range expected {
+		assert.Same(t, expected[i], actual[i], "index %d", i)
+	}
+}
@@ -0,0 +1,49 @@
+package fixify
+
+import "testing"
+
+// Edge is a connector of a child model of type C to a parent model of type P.
+// It is passed to [NewModel] as Connecter[C], and to [Child] and [Parent] to connect models,
+// so that connecting models of incompatible types is rejected at compile time.
+type Edge[C, P any] struct {
+	Connecter[C]
+	label any
+}
+
+// EdgeFunc translates a function of the form func(t testing.TB, childModel *C, parentModel *P) into Edge[C, P].
+// It is the typed version of [ConnectorFunc].
+func EdgeFunc[C, P any](f func(t testing.TB, childModel *C, parentModel *P)) Edge[C, P] {
+	return Edge[C, P]{Connecter: ConnectorFunc(f)}
+}
+
+// EdgeFuncWithLabel translates a function of the form func(t testing.TB, childModel *C, parentModel *P) with a label into Edge[C, P].
+// It is the typed version of [ConnectorFuncWithLabel].
+func EdgeFuncWithLabel[C, P any, L comparable](label L, f func(t testing.TB, childModel *C, parentModel *P)) Edge[C, P] {
+	return Edge[C, P]{Connecter: ConnectorFuncWithLabel(label, f), label: label}
+}
+
+// Child registers children models of the parent connected by the edge, and returns the parent.
+// Unlike [Model.With], the types of the models are checked at compile time,
+// and the children are connected with the label of the edge if any.
+// The edge must be one of the connectors of the children passed to [NewModel];
+// otherwise, the error is reported by [New] with the location of the call.
+func Child[P, C any](parent *Model[P], edge Edge[C, P], children ...*Model[C]) *Model[P] {
+	loc := caller(1)
+	for _, c := range children {
+		// the errors are recorded in the parent as in With, because the children may not be passed to New.
+		err, warn := c.connectParent(edge.label, parent, loc)
+		if err != nil {
+			parent.errs = append(parent.errs, err)
+		}
+		if warn != nil {
+			parent.warns = append(parent.warns, warn)
+		}
+	}
+	return parent
+}
+
+// Parent registers a parent model of the child connected by the edge, and returns the child.
+// It is the typed version of [Model.WithParent] and [Model.WithParentAs].
+func Parent[C, P any](child *Model[C], edge Edge[C, P], parent *Model[P]) *Model[C] {
+	return child.withParentAs(edge.label, parent, caller(1))
+}
@@ -0,0 +1,126 @@
+package fixify_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleChild() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	// fixify.Child(Company(), BookLibrary, TypedBook()) does not compile.
+	f := fixify.New(t,
+		fixify.Child(Library(), BookLibrary,
+			TypedBook(),
+			TypedBook(),
+		),
+	)
+	f.Apply(func(v any) error {
+		if v, ok := v.(*model.Library); ok {
+			v.ID = 1
+		}
+		return nil
+	})
+	for _, b := range filter[*model.Book](f.All()) {
+		fmt.Println("LibraryID:", b.LibraryID)
+	}
+	// Output:
+	// LibraryID: 1
+	// LibraryID: 1
+}
+
+func ExampleParent() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	var alice, bob *fixify.Model[model.User]
+	f := fixify.New(t,
+		fixify.Parent(
+			fixify.Parent(TypedFollow(), FollowFollower, User("alice").Bind(&alice)),
+			FollowFollowee, User("bob").Bind(&bob),
+		),
+	)
+	f.Apply(func(v any) error {
+		if v, ok := v.(*model.User); ok {
+			v.ID = int64(len(v.Name))
+		}
+		return nil
+	})
+	follow := filter[*model.Follow](f.All())[0]
+	fmt.Println("FollowerID:", follow.FollowerID, "FolloweeID:", follow.FolloweeID)
+	// Output:
+	// FollowerID: 5 FolloweeID: 3
+}
+
+func TestChild(t *testing.T) {
+	t.Parallel()
+	t.Run("labeled edge", func(t *testing.T) {
+		t.Parallel()
+		var follow *fixify.Model[model.Follow]
+		var alice, bob *fixify.Model[model.User]
+		f := fixify.New(t,
+			fixify.Child(User("alice").Bind(&alice), FollowFollower, TypedFollow().Bind(&follow)),
+			fixify.Child(User("bob").Bind(&bob), FollowFollowee, follow),
+		)
+		f.Apply(func(v any) error {
+			if v, ok := v.(*model.User); ok {
+				v.ID = int64(len(v.Name))
+			}
+			return nil
+		})
+		assert.Equal(t, alice.Value().ID, follow.Value().FollowerID)
+		assert.Equal(t, bob.Value().ID, follow.Value().FolloweeID)
+	})
+
+	t.Run("edge not registered", func(t *testing.T) {
+		t.Parallel()
+		assertInvalidModels(t, "cannot connect: child *model.Book -> parent *model.Library",
+			fixify.Child(Library(), BookLibrary, fixify.NewModel(new(model.Book))),
+		)
+	})
+
+	t.Run("label not registered", func(t *testing.T) {
+		t.Parallel()
+		assertInvalidModels(t, `cannot connect: child *model.Follow -> parent *model.User with label "follower" (accepted: "followee")`,
+			fixify.Child(User("alice"), FollowFollower, fixify.NewModel(new(model.Follow), FollowFollowee)),
+		)
+	})
+}
+
+func TestParent(t *testing.T) {
+	t.Parallel()
+	t.Run("edge not registered", func(t *testing.T) {
+		t.Parallel()
+		assertInvalidModels(t, "cannot connect: child *model.Book -> parent *model.Library",
+			fixify.Parent(fixify.NewModel(new(model.Book)), BookLibrary, Library()),
+		)
+	})
+}
+
+// BookLibrary connects a book to a library.
+var BookLibrary = fixify.EdgeFunc(func(_ testing.TB, book *model.Book, library *model.Library) {
+	book.LibraryID = library.ID
+})
+
+// FollowFollower connects a follow to the follower.
+var FollowFollower = fixify.EdgeFuncWithLabel("follower", func(_ testing.TB, follow *model.Follow, follower *model.User) {
+	follow.FollowerID = follower.ID
+})
+
+// FollowFollowee connects a follow to the followee.
+var FollowFollowee = fixify.EdgeFuncWithLabel("followee", func(_ testing.TB, follow *model.Follow, followee *model.User) {
+	follow.FolloweeID = followee.ID
+})
+
+// TypedBook represents a fixture for the book model with a typed edge.
+func TypedBook() *fixify.Model[model.Book] {
+	return fixify.NewModel(new(model.Book), BookLibrary)
+}
+
+// TypedFollow represents a fixture for the follow model with typed edges.
+func TypedFollow() *fixify.Model[model.Follow] {
+	return fixify.NewModel(new(model.Follow), FollowFollower, FollowFollowee)
+}
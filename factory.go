@@ -0,0 +1,69 @@
+package fixify
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Factory creates a default model of a type.
+// Use [FactoryFunc] to get one and set it to [Config.Factories].
+type Factory interface {
+	// modelType returns the pointer type of the model created by the factory.
+	modelType() reflect.Type
+	newModel() IModel
+}
+
+// factoryFunc[T] implements Factory.
+type factoryFunc[T any] func() *Model[T]
+
+var _ Factory = factoryFunc[int](nil)
+
+//nolint:unused // it is necessary to implement the interface Factory.
+func (f factoryFunc[T]) modelType() reflect.Type {
+	return reflect.TypeFor[*T]()
+}
+
+//nolint:unused // it is necessary to implement the interface Factory.
+func (f factoryFunc[T]) newModel() IModel {
+	return f()
+}
+
+// FactoryFunc translates a function of the form func() *Model[T] into Factory.
+func FactoryFunc[T any](f func() *Model[T]) Factory {
+	return factoryFunc[T](f)
+}
+
+// createParents creates missing parents of the models with the factories recursively,
+// and returns the models together with the created ones.
+// Models in existing are neither returned nor given parents.
+func createParents(all []IModel, existing map[IModel]struct{}, factories map[reflect.Type]Factory) ([]IModel, error) {
+	set := make(map[IModel]struct{}, len(all)+len(existing))
+	for c := range existing {
+		set[c] = struct{}{}
+	}
+	for _, c := range all {
+		set[c] = struct{}{}
+	}
+	// chains holds the types of the descendants that a created model is created for.
+	chains := make(map[IModel][]reflect.Type)
+	for i := 0; i < len(all); i++ {
+		c := all[i]
+		chain := append(slices.Clone(chains[c]), reflect.TypeOf(c.model()))
+		created, err := c.createParents(factories, chain)
+		if err != nil {
+			return nil, err
+		}
+		// a created model may be connected to other models in its factory.
+		// created models are not lazy, so that collect never fails.
+		connected, _ := collect(created)
+		for _, cc := range connected {
+			if _, ok := set[cc]; ok {
+				continue
+			}
+			set[cc] = struct{}{}
+			chains[cc] = chain
+			all = append(all, cc)
+		}
+	}
+	return all, nil
+}
@@ -0,0 +1,126 @@
+package fixify_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// defaultFactories are factories shared by tests.
+var defaultFactories = []fixify.Factory{
+	fixify.FactoryFunc(Company),
+	fixify.FactoryFunc(func() *fixify.Model[model.Department] {
+		return Department("default")
+	}),
+}
+
+func ExampleConfig_New_factories() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	f := fixify.Config{Factories: defaultFactories}.New(t,
+		// a department and a company are created automatically.
+		Employee(),
+	)
+	models := f.All()
+	fmt.Println("Number of companies:", len(filter[*model.Company](models)))
+	fmt.Println("Number of departments:", len(filter[*model.Department](models)))
+	fmt.Println("Number of employees:", len(filter[*model.Employee](models)))
+	// Output:
+	// Number of companies: 1
+	// Number of departments: 1
+	// Number of employees: 1
+}
+
+func TestConfig_New_factories(t *testing.T) {
+	t.Parallel()
+	setter := func(v any) error {
+		switch v := v.(type) {
+		case *model.Company:
+			v.ID = 1
+		case *model.Department:
+			v.ID = 2
+		case *model.Employee:
+			v.ID = 3
+		}
+		return nil
+	}
+
+	t.Run("ancestors", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.Config{Factories: defaultFactories, Strict: true}.New(t,
+			Employee(),
+		)
+		f.Apply(setter)
+		assert.ElementsMatch(t, []*model.Company{{ID: 1}}, filter[*model.Company](f.All()))
+		assert.ElementsMatch(t, []*model.Department{{ID: 2, CompanyID: 1, Name: "default"}}, filter[*model.Department](f.All()))
+		assert.ElementsMatch(t, []*model.Employee{{ID: 3, DepartmentID: 2}}, filter[*model.Employee](f.All()))
+	})
+
+	t.Run("existing parent", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.Config{Factories: defaultFactories}.New(t,
+			Department("finance").With(
+				Employee(),
+			),
+		)
+		f.Apply(setter)
+		assert.ElementsMatch(t, []*model.Department{{ID: 2, CompanyID: 1, Name: "finance"}}, filter[*model.Department](f.All()))
+		assert.Len(t, f.All(), 3)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		t.Parallel()
+		factories := append(slices.Clone(defaultFactories), fixify.FactoryFunc(func() *fixify.Model[model.Department] {
+			return Department("sales")
+		}))
+		f := fixify.Config{Factories: factories}.New(t,
+			Employee(),
+		)
+		got := filter[*model.Department](f.All())
+		assert.Len(t, got, 1)
+		assert.Equal(t, "sales", got[0].Name)
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.Config{Factories: []fixify.Factory{fixify.FactoryFunc(func() *fixify.Model[model.User] {
+			return User("default")
+		})}}.New(t,
+			Follow(),
+		)
+		assert.Len(t, filter[*model.User](f.All()), 2)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.Config{Factories: []fixify.Factory{fixify.FactoryFunc(func() *fixify.Model[model.User] {
+			return User("default")
+		})}}.New(t,
+			Ticket(),
+		)
+		assert.Len(t, f.All(), 1)
+	})
+
+	t.Run("without factory", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.Config{Factories: []fixify.Factory{fixify.FactoryFunc(Company)}, Strict: true}.New(dt,
+			Employee(),
+		)
+		assert.Equal(t, []string{"missing parents: *model.Employee has no parent of type *model.Department"}, dt.messages)
+	})
+
+	t.Run("cyclic", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		f := fixify.Config{Factories: []fixify.Factory{fixify.FactoryFunc(Cyclic)}}.New(dt,
+			Cyclic(),
+		)
+		assert.Empty(t, f.All())
+		assert.Equal(t, []string{"failed to create parents: cyclic factories: *model.Cyclic is required by its descendant"}, dt.messages)
+	})
+}
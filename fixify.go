@@ -1,8 +1,10 @@
 package fixify
 
 import (
+	"errors"
 	"fmt"
-	"math/rand/v2"
+	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -13,13 +15,24 @@ type Model[T any] struct {
 	connectorFuncs []Connecter[T]
 
 	parentSet map[IModel]struct{}
+	// parentList holds the parents in the order they are registered.
+	parentList []IModel
 	// nil for any represents no label.
 	childSet map[IModel]map[any]struct{}
+	// childList holds the children in the order they are registered.
+	childList []IModel
+	// lazyParents are parents registered with Lazy, which are resolved in New.
+	lazyParents []lazyEdge
+	// errs are errors occurred in constructing the model, which are reported in New.
+	errs []error
+	// warns are suspicious constructions of the model, which are reported in New.
+	warns []error
 }
 
 var _ IModel = &Model[int]{}
 
 // IModel represents a set of models that can be connected to each other.
+// It is implemented only by fixify; types in other packages can be passed as IModel through [Adapt].
 type IModel interface {
 	// Children() []IModel
 	// Descendants() []IModel
@@ -33,43 +46,92 @@ type IModel interface {
 	children() []IModel
 	labels(child IModel) []any
 	canConnect(parent any, label any) bool
-	connectors() []func(t testing.TB, parent any, label any)
+	connectors(parent any, label any) []func(t testing.TB) error
+	updateParents(tb testing.TB, hook connectHook) ([]IModel, error)
+	connectBeforeVisit(tb testing.TB, hook connectHook) error
+	ambiguousAncestors() []error
+	acceptedKeys() []parentKey
+	resolveLazy()
+	constructionErrors() []error
+	addConstructionError(err error)
+	warnings() []error
+	missingParents(strict bool) []error
+	createParents(factories map[reflect.Type]Factory, chain []reflect.Type) ([]IModel, error)
 }
 
 // NewModel is a constructor of Model.
+// Multiple connectors of the same kind for the same parent type and label are ambiguous,
+// which [New] reports as a warning, or as an error in the strict mode.
 func NewModel[T any](model *T, connectorFuncs ...Connecter[T]) *Model[T] {
-	return &Model[T]{
+	m := &Model[T]{
 		v:              model,
 		connectorFuncs: connectorFuncs,
 		parentSet:      map[IModel]struct{}{},
 		childSet:       map[IModel]map[any]struct{}{},
 	}
+	loc := caller(1)
+	for i, f := range connectorFuncs {
+		for _, g := range connectorFuncs[:i] {
+			if f.kind() == g.kind() && slices.Equal(f.parentKeys(), g.parentKeys()) {
+				m.warns = append(m.warns, loc.wrap(fmt.Errorf("ambiguous connectors: %T has multiple connectors for %s", m.Value(), describeKeys(f.parentKeys()))))
+				break
+			}
+		}
+	}
+	return m
 }
 
 // Connector is an interface that incorporates the connector functions of the form func(t testing.TB, childModel *U, parentModel *V).
 // It is used to establish connections between different model types.
 // Use [ConnectorFunc] to get one.
+// Wrap it with [Required] or [Optional] to declare whether the parent is required.
 type Connecter[T any] interface {
 	canConnect(parentModel any, label any) bool
-	connect(t testing.TB, childModel *T, parentModel any, label any)
-	// label() any
+	connect(t testing.TB, childModel *T, parentModel any, label any) error
+	// connectParents calls the connector function with a parent for each of parentKeys.
+	connectParents(t testing.TB, childModel *T, parentModels []any) error
+	// parentKeys returns the types and the labels of the parent models the connector needs.
+	parentKeys() []parentKey
+	requirement() requirement
+	kind() connectorKind
+}
+
+// connectorKind represents when a connector is called in Fixture.Apply.
+type connectorKind int
+
+const (
+	// connectorKindForward represents a connector which updates the child before the child is visited.
+	connectorKindForward connectorKind = iota
+	// connectorKindBackward represents a connector which updates the parent after the child is visited.
+	connectorKindBackward
+	// connectorKindAncestor represents a connector which updates the child with the nearest ancestor of a type.
+	connectorKindAncestor
+	// connectorKindMulti represents a connector which updates the child with multiple parents at once.
+	connectorKindMulti
+)
+
+// parentKey identifies parents which a connector connects to.
+type parentKey struct {
+	typ   reflect.Type
+	label any
 }
 
 // connectParentFunc[U, V] implements Connecter[U].
-type connectParentFunc[U, V any] func(t testing.TB, childModel *U, parentModel *V)
+type connectParentFunc[U, V any] func(t testing.TB, childModel *U, parentModel *V) error
 
 var _ Connecter[int] = connectParentFunc[int, string](nil)
 
 //nolint:unused // it is necessary to implement the interface Connecter[U].
-func (f connectParentFunc[U, V]) connect(tb testing.TB, childModel *U, parentModel any, label any) {
+func (f connectParentFunc[U, V]) connect(tb testing.TB, childModel *U, parentModel any, label any) error {
 	tb.Helper()
 	if label != nil {
 		// connectParentFunc does not support label.
-		return
+		return nil
 	}
 	if v, ok := parentModel.(*V); ok {
-		f(tb, childModel, v)
+		return f(tb, childModel, v)
 	}
+	return nil
 }
 
 //nolint:unused // it is necessary to implement the interface Connecter[U].
@@ -82,6 +144,27 @@ func (f connectParentFunc[U, V]) canConnect(parentModel any, label any) bool {
 	return ok
 }
 
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f connectParentFunc[U, V]) parentKeys() []parentKey {
+	return []parentKey{{typ: reflect.TypeFor[*V]()}}
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f connectParentFunc[U, V]) connectParents(tb testing.TB, childModel *U, parentModels []any) error {
+	tb.Helper()
+	return f.connect(tb, childModel, parentModels[0], nil)
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f connectParentFunc[U, V]) requirement() requirement {
+	return requirementDefault
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f connectParentFunc[U, V]) kind() connectorKind {
+	return connectorKindForward
+}
+
 // connectParentFunc[U, V, L] implements Connecter[U].
 type connectParentFuncWithLabel[U, V any, L comparable] struct {
 	label L
@@ -89,17 +172,18 @@ type connectParentFuncWithLabel[U, V any, L comparable] struct {
 }
 
 //nolint:unused // it is necessary to implement the interface Connecter[U].
-func (f *connectParentFuncWithLabel[U, V, L]) connect(tb testing.TB, childModel *U, parentModel any, label any) {
+func (f *connectParentFuncWithLabel[U, V, L]) connect(tb testing.TB, childModel *U, parentModel any, label any) error {
 	tb.Helper()
 	if _, ok := label.(L); !ok {
-		return
+		return nil
 	}
 	if l, ok := label.(L); ok && l != f.label {
-		return
+		return nil
 	}
 	if v, ok := parentModel.(*V); ok {
-		f.fn(tb, childModel, v)
+		return f.fn(tb, childModel, v)
 	}
+	return nil
 }
 
 //nolint:unused // it is necessary to implement the interface Connecter[U].
@@ -114,27 +198,85 @@ func (f *connectParentFuncWithLabel[U, V, L]) canConnect(parentModel any, label
 	return ok
 }
 
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f *connectParentFuncWithLabel[U, V, L]) parentKeys() []parentKey {
+	return []parentKey{{typ: reflect.TypeFor[*V](), label: f.label}}
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f *connectParentFuncWithLabel[U, V, L]) connectParents(tb testing.TB, childModel *U, parentModels []any) error {
+	tb.Helper()
+	return f.connect(tb, childModel, parentModels[0], f.label)
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f *connectParentFuncWithLabel[U, V, L]) requirement() requirement {
+	return requirementDefault
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f *connectParentFuncWithLabel[U, V, L]) kind() connectorKind {
+	return connectorKindForward
+}
+
 // ConnectorFunc translates a function of the form func(t testing.TB, childModel *U, parentModel *V) into Connecter[U].
 func ConnectorFunc[U, V any](f func(t testing.TB, childModel *U, parentModel *V)) Connecter[U] {
-	return connectParentFunc[U, V](f)
+	return ignoreError(f)
+}
+
+// ConnectorFuncE translates a function of the form func(childModel *U, parentModel *V) error into Connecter[U].
+// Unlike [ConnectorFunc], it does not depend on testing.TB, so that the function can be reused outside tests.
+// An error returned by the function is reported by [Fixture.Apply] in the same way as an error returned by the visitor.
+func ConnectorFuncE[U, V any](f func(childModel *U, parentModel *V) error) Connecter[U] {
+	return connectParentFunc[U, V](func(_ testing.TB, childModel *U, parentModel *V) error {
+		return f(childModel, parentModel)
+	})
 }
 
 // ConnectorFuncWithLabel translates a function of the form func(t testing.TB, childModel *U, parentModel *V) with a label into Connecter[U].
 // With different labels, you can connect the same parent model in different ways.
 // See an example in [Model.WithParentAs].
 func ConnectorFuncWithLabel[U, V any, L comparable](label L, f func(t testing.TB, childModel *U, parentModel *V)) Connecter[U] {
-	return &connectParentFuncWithLabel[U, V, L]{label: label, fn: connectParentFunc[U, V](f)}
+	return &connectParentFuncWithLabel[U, V, L]{label: label, fn: ignoreError(f)}
+}
+
+// ignoreError translates a connector function without an error into connectParentFunc.
+func ignoreError[U, V any](f func(t testing.TB, childModel *U, parentModel *V)) connectParentFunc[U, V] {
+	return func(tb testing.TB, childModel *U, parentModel *V) error {
+		tb.Helper()
+		f(tb, childModel, parentModel)
+		return nil
+	}
 }
 
 // With registers children models.
+// A child which cannot be connected is not registered,
+// and the error is reported by [New] with the location of the call.
 func (m *Model[T]) With(children ...IModel) *Model[T] {
+	loc := caller(1)
 	for _, c := range children {
+		if l, ok := c.(*lazyModel); ok {
+			if !l.eager {
+				m.errs = append(m.errs, loc.wrap(fmt.Errorf("lazy model cannot be a child of %T: use WithParent instead", m.Value())))
+				continue
+			}
+			var err error
+			if c, err = l.resolve(); err != nil {
+				m.errs = append(m.errs, loc.wrap(err))
+				continue
+			}
+		}
 		if _, ok := m.parentSet[c]; ok {
 			// cyclic dependency is not allowed because we cannot sort models in a topological order.
-			panic(fmt.Errorf("cyclic dependency: %T <-> %T", m.Value(), c.model()))
+			m.errs = append(m.errs, loc.wrap(fmt.Errorf("cyclic dependency: %T <-> %T", m.Value(), c.model())))
+			continue
 		}
 		if !c.canConnect(m.Value(), nil) {
-			panic(fmt.Errorf("cannot connect: child %T -> parent %T", c.model(), m.Value()))
+			m.errs = append(m.errs, loc.wrap(cannotConnectError(c, m.Value(), nil)))
+			continue
+		}
+		if slices.Contains(m.labels(c), nil) {
+			m.warns = append(m.warns, loc.wrap(fmt.Errorf("duplicate edge: child %T -> parent %T", c.model(), m.Value())))
 		}
 		m.setChild(c, nil)
 		c.setParent(m)
@@ -145,21 +287,69 @@ func (m *Model[T]) With(children ...IModel) *Model[T] {
 
 // WithParent registers a parent model.
 func (m *Model[T]) WithParent(parent IModel) *Model[T] {
-	return m.WithParentAs(nil, parent)
+	return m.withParentAs(nil, parent, caller(1))
 }
 
 // WithParentAs registers a parent model with a label.
+// parent may be a lazy reference created by [Lazy], which is resolved in [New].
+// A parent which cannot be connected is not registered,
+// and the error is reported by [New] with the location of the call.
 func (m *Model[T]) WithParentAs(label any, parent IModel) *Model[T] {
+	return m.withParentAs(label, parent, caller(1))
+}
+
+// withParentAs registers a parent model with a label.
+// loc is the location where the registration is requested.
+func (m *Model[T]) withParentAs(label any, parent IModel, loc location) *Model[T] {
+	err, warn := m.connectParent(label, parent, loc)
+	if err != nil {
+		m.errs = append(m.errs, err)
+		// the error is also recorded in the parent, because the child may not be passed to New.
+		// New reports it only once even if both are passed.
+		if l, ok := parent.(*lazyModel); ok {
+			parent = l.resolved
+		}
+		if !isNil(parent) {
+			parent.addConstructionError(err)
+		}
+	}
+	if warn != nil {
+		m.warns = append(m.warns, warn)
+	}
+	return m
+}
+
+// connectParent registers a parent model with a label.
+// It returns an error if the parent cannot be connected, and a warning if the edge is duplicated.
+// loc is the location where the registration is requested.
+func (m *Model[T]) connectParent(label any, parent IModel, loc location) (err error, warn error) {
+	if l, ok := parent.(*lazyModel); ok {
+		if !l.eager {
+			m.lazyParents = append(m.lazyParents, lazyEdge{label: label, parent: l, loc: loc})
+			return nil, nil
+		}
+		var err error
+		if parent, err = l.resolve(); err != nil {
+			return loc.wrap(err), nil
+		}
+	}
 	if m.hasChild(parent) {
 		// cyclic dependency is not allowed because we cannot sort models in a topological order.
-		panic(fmt.Errorf("cyclic dependency: %T <-> %T", m.Value(), parent.model()))
+		return loc.wrap(fmt.Errorf("cyclic dependency: %T <-> %T", m.Value(), parent.model())), nil
 	}
 	if !m.canConnect(parent.model(), label) {
-		panic(fmt.Errorf("cannot connect: child %T -> parent %T", m.Value(), parent.model()))
+		return loc.wrap(cannotConnectError(m, parent.model(), label)), nil
+	}
+	if slices.Contains(parent.labels(m), label) {
+		if label != nil {
+			warn = loc.wrap(fmt.Errorf("duplicate edge: child %T -> parent %T with label %#v", m.Value(), parent.model(), label))
+		} else {
+			warn = loc.wrap(fmt.Errorf("duplicate edge: child %T -> parent %T", m.Value(), parent.model()))
+		}
 	}
 	parent.setChild(m, label)
 	m.setParent(parent)
-	return m
+	return nil, warn
 }
 
 // Bind sets the pointer to the model.
@@ -181,18 +371,23 @@ func (m *Model[T]) model() any {
 
 // setParent sets the parent model.
 func (m *Model[T]) setParent(parent IModel) {
+	if _, ok := m.parentSet[parent]; ok {
+		return
+	}
 	m.parentSet[parent] = struct{}{}
+	m.parentList = append(m.parentList, parent)
 }
 
-// parents returns the parent models.
+// parents returns the parent models in the order they are registered.
 func (m *Model[T]) parents() []IModel {
-	return keys(m.parentSet)
+	return m.parentList
 }
 
 // setChild sets the child model.
 func (m *Model[T]) setChild(child IModel, label any) {
 	if m.childSet[child] == nil {
 		m.childSet[child] = make(map[any]struct{})
+		m.childList = append(m.childList, child)
 	}
 	m.childSet[child][label] = struct{}{}
 }
@@ -202,9 +397,9 @@ func (m *Model[T]) hasChild(child IModel) bool {
 	return ok
 }
 
-// children returns the children models.
+// children returns the children models in the order they are registered.
 func (m *Model[T]) children() []IModel {
-	return keys(m.childSet)
+	return m.childList
 }
 
 // labels returns the labels of the child model.
@@ -216,18 +411,131 @@ func (m *Model[T]) labels(child IModel) []any {
 	return labels
 }
 
-// connectors returns the connector functions.
-func (m *Model[T]) connectors() []func(t testing.TB, parent any, label any) {
-	funcs := make([]func(t testing.TB, parent any, label any), 0, len(m.connectorFuncs))
+// connectors returns the connector functions for the parent with the label called before the model is visited.
+func (m *Model[T]) connectors(parent any, label any) []func(t testing.TB) error {
+	var funcs []func(t testing.TB) error
 	for _, f := range m.connectorFuncs {
-		funcs = append(funcs, func(tb testing.TB, parent any, label any) {
+		if f.kind() != connectorKindForward || !f.canConnect(parent, label) {
+			continue
+		}
+		funcs = append(funcs, func(tb testing.TB) error {
 			tb.Helper()
-			f.connect(tb, m.v, parent, label)
+			return f.connect(tb, m.v, parent, label)
 		})
 	}
 	return funcs
 }
 
+// updateParents calls the backward connectors with the parents through hook and returns the parents updated by them.
+func (m *Model[T]) updateParents(tb testing.TB, hook connectHook) ([]IModel, error) {
+	tb.Helper()
+	var updated []IModel
+	for _, p := range m.parentList {
+		labels := p.labels(m)
+		called := false
+		for _, f := range m.connectorFuncs {
+			if f.kind() != connectorKindBackward {
+				continue
+			}
+			for _, label := range labels {
+				if !f.canConnect(p.model(), label) {
+					continue
+				}
+				if err := hook(m.v, p.model(), label, func() error {
+					return f.connect(tb, m.v, p.model(), label)
+				}); err != nil {
+					return nil, fmt.Errorf("child %T -> parent %T: %w", m.Value(), p.model(), err)
+				}
+				called = true
+			}
+		}
+		if called {
+			updated = append(updated, p)
+		}
+	}
+	return updated, nil
+}
+
+// connectBeforeVisit calls the connectors which take parents other than a single direct parent through hook,
+// i.e. the ancestor connectors and the multi-parent connectors.
+func (m *Model[T]) connectBeforeVisit(tb testing.TB, hook connectHook) error {
+	tb.Helper()
+	for _, f := range m.connectorFuncs {
+		switch f.kind() {
+		case connectorKindAncestor:
+			key := f.parentKeys()[0]
+			ancestors := nearestAncestors(m, key.typ)
+			if len(ancestors) != 1 {
+				// no ancestor or ambiguous ancestors, which are reported in New.
+				continue
+			}
+			ancestor := ancestors[0].model()
+			if err := hook(m.v, ancestor, nil, func() error {
+				return f.connectParents(tb, m.v, []any{ancestor})
+			}); err != nil {
+				return fmt.Errorf("child %T -> ancestor %T: %w", m.Value(), ancestor, err)
+			}
+		case connectorKindMulti:
+			for _, parents := range combinations(m.candidates(f)) {
+				if err := hook(m.v, parents, nil, func() error {
+					return f.connectParents(tb, m.v, parents)
+				}); err != nil {
+					return fmt.Errorf("child %T -> parents %s: %w", m.Value(), typeNames(parents), err)
+				}
+			}
+		case connectorKindForward, connectorKindBackward:
+		}
+	}
+	return nil
+}
+
+// candidates returns the parents for each parent key of the connector.
+func (m *Model[T]) candidates(f Connecter[T]) [][]any {
+	keys := f.parentKeys()
+	candidates := make([][]any, 0, len(keys))
+	for _, key := range keys {
+		parents := m.parentsOf(key)
+		models := make([]any, 0, len(parents))
+		for _, p := range parents {
+			models = append(models, p.model())
+		}
+		candidates = append(candidates, models)
+	}
+	return candidates
+}
+
+// ambiguousAncestors returns errors for the ancestor connectors that have multiple nearest ancestors.
+func (m *Model[T]) ambiguousAncestors() []error {
+	var errs []error
+	for _, f := range m.connectorFuncs {
+		if f.kind() != connectorKindAncestor {
+			continue
+		}
+		typ := f.parentKeys()[0].typ
+		if n := len(nearestAncestors(m, typ)); n > 1 {
+			errs = append(errs, fmt.Errorf("%T has %d nearest ancestors of type %s", m.Value(), n, typ))
+		}
+	}
+	return errs
+}
+
+// acceptedKeys returns the types and the labels of the parents which the model accepts directly.
+func (m *Model[T]) acceptedKeys() []parentKey {
+	var keys []parentKey
+	for _, f := range m.connectorFuncs {
+		if f.kind() == connectorKindAncestor {
+			// an ancestor connector does not accept a parent directly.
+			continue
+		}
+		for _, key := range f.parentKeys() {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
 // canConnect returns true if the model can connect to the parent.
 func (m *Model[T]) canConnect(parent any, label any) bool {
 	for _, f := range m.connectorFuncs {
@@ -238,6 +546,117 @@ func (m *Model[T]) canConnect(parent any, label any) bool {
 	return false
 }
 
+// resolveLazy resolves the lazy parents and connects them to the model.
+func (m *Model[T]) resolveLazy() {
+	lazyParents := m.lazyParents
+	m.lazyParents = nil
+	for _, e := range lazyParents {
+		parent, err := e.parent.resolve()
+		if err != nil {
+			m.errs = append(m.errs, e.loc.wrap(err))
+			continue
+		}
+		m.withParentAs(e.label, parent, e.loc)
+	}
+}
+
+// addConstructionError records an error occurred in connecting the model to another model.
+func (m *Model[T]) addConstructionError(err error) {
+	m.errs = append(m.errs, err)
+}
+
+// constructionErrors returns the errors occurred in constructing the model.
+func (m *Model[T]) constructionErrors() []error {
+	return m.errs
+}
+
+// warnings returns the suspicious constructions of the model.
+func (m *Model[T]) warnings() []error {
+	return m.warns
+}
+
+// missingParents returns errors for the required connectors that have no parent to connect to.
+// In strict mode, connectors not marked as optional are also regarded as required.
+func (m *Model[T]) missingParents(strict bool) []error {
+	var errs []error
+	for _, f := range m.connectorFuncs {
+		if !f.requirement().isRequired(strict) {
+			continue
+		}
+		for _, key := range f.parentKeys() {
+			if m.hasParent(f.kind(), key) {
+				continue
+			}
+			switch {
+			case f.kind() == connectorKindAncestor:
+				errs = append(errs, fmt.Errorf("%T has no ancestor of type %s", m.Value(), key.typ))
+			case key.label != nil:
+				errs = append(errs, fmt.Errorf("%T has no parent of type %s with label %#v", m.Value(), key.typ, key.label))
+			default:
+				errs = append(errs, fmt.Errorf("%T has no parent of type %s", m.Value(), key.typ))
+			}
+		}
+	}
+	return errs
+}
+
+// createParents creates parents with the factories for the connectors not marked as optional that have no parent.
+// chain is the types of the model and the descendants that the model is created for.
+// It returns an error without connecting any parent if one of the parents to be created has a type in chain.
+func (m *Model[T]) createParents(factories map[reflect.Type]Factory, chain []reflect.Type) ([]IModel, error) {
+	var targets []parentKey
+	for _, f := range m.connectorFuncs {
+		if f.requirement() == requirementOptional || f.kind() == connectorKindAncestor {
+			// an ancestor is created through the parents.
+			continue
+		}
+		for _, key := range f.parentKeys() {
+			if _, ok := factories[key.typ]; !ok || m.hasParent(f.kind(), key) {
+				continue
+			}
+			if slices.Contains(targets, key) {
+				// connectors of different kinds may share a parent.
+				continue
+			}
+			if slices.Contains(chain, key.typ) {
+				// a factory creating a model of the same type as a descendant would create models endlessly.
+				return nil, fmt.Errorf("cyclic factories: %s is required by its descendant", key.typ)
+			}
+			targets = append(targets, key)
+		}
+	}
+	created := make([]IModel, 0, len(targets))
+	for _, key := range targets {
+		p := factories[key.typ].newModel()
+		m.withParentAs(key.label, p, location{})
+		created = append(created, p)
+	}
+	return created, nil
+}
+
+// hasParent returns true if the model has a parent identified by key.
+// For an ancestor connector, it returns true if the model has an ancestor of the type.
+func (m *Model[T]) hasParent(kind connectorKind, key parentKey) bool {
+	if kind == connectorKindAncestor {
+		return len(nearestAncestors(m, key.typ)) > 0
+	}
+	return len(m.parentsOf(key)) > 0
+}
+
+// parentsOf returns the parents identified by key.
+func (m *Model[T]) parentsOf(key parentKey) []IModel {
+	var parents []IModel
+	for _, p := range m.parentList {
+		if reflect.TypeOf(p.model()) != key.typ {
+			continue
+		}
+		if slices.Contains(p.labels(m), key.label) {
+			parents = append(parents, p)
+		}
+	}
+	return parents
+}
+
 // func (m *Model[T]) Children() []IModel {
 // }
 
@@ -247,51 +666,142 @@ func (m *Model[T]) canConnect(parent any, label any) bool {
 // Fixture collects models and resolves their dependencies.
 type Fixture struct {
 	t          testing.TB
+	cfg        Config
 	connectors []IModel
+	// set is the set of connectors.
+	set map[IModel]struct{}
+	// visited is the set of models visited by Apply.
+	visited map[IModel]struct{}
+	// order sorts the models added to the fixture instead of cfg if it is not nil.
+	order func(all []IModel) []IModel
+	// hooks are registered by WithHooks.
+	hooks []Hooks
 }
 
+// New collects the models and the models connected to them, and sorts them in a topological order.
+// The order of models without dependencies between them is random.
 func New(tb testing.TB, fixtures ...IModel) *Fixture {
+	tb.Helper()
+	return Config{}.New(tb, fixtures...)
+}
+
+// New is like [New] but configured with cfg.
+func (cfg Config) New(tb testing.TB, fixtures ...IModel) *Fixture {
 	tb.Helper()
 	f := &Fixture{
-		t: tb,
+		t:       tb,
+		cfg:     cfg,
+		set:     map[IModel]struct{}{},
+		visited: map[IModel]struct{}{},
 	}
-	// 順序をあえてランダムにする
-	all := collect(fixtures)
-	rand.Shuffle(len(all), func(i, j int) {
-		all[i], all[j] = all[j], all[i]
-	})
-	numParents := make(map[IModel]int, len(all))
-	for _, c := range all {
-		numParents[c] = len(c.parents())
+	f.add(fixtures)
+	return f
+}
+
+// add collects the models and the models connected to them which are not in the fixture yet,
+// and appends them to the fixture in a topological order.
+func (f *Fixture) add(fixtures []IModel) {
+	f.t.Helper()
+	tb, cfg := f.t, f.cfg
+	collected, errs := collect(fixtures)
+	all := make([]IModel, 0, len(collected))
+	for _, m := range collected {
+		if _, ok := f.set[m]; !ok {
+			all = append(all, m)
+		}
 	}
-	// topological sort
-	sorted := make([]IModel, 0, len(all))
-	for {
-		allZeros := true
-		for _, c := range all {
-			if c != nil {
-				allZeros = false
-				break
-			}
+	if len(cfg.Factories) > 0 {
+		factories := make(map[reflect.Type]Factory, len(cfg.Factories))
+		for _, factory := range cfg.Factories {
+			factories[factory.modelType()] = factory
 		}
-		if allZeros {
-			break
+		var err error
+		all, err = createParents(all, f.set, factories)
+		if err != nil {
+			tb.Fatalf("failed to create parents: %v", err)
+			return
 		}
-		for i, c := range all {
-			if c == nil {
-				continue
+	}
+	// an error recorded in both a child and its parent is reported once.
+	reported := make(map[error]struct{})
+	for _, m := range all {
+		for _, err := range m.constructionErrors() {
+			if _, ok := reported[err]; !ok {
+				reported[err] = struct{}{}
+				errs = append(errs, err)
 			}
-			if numParents[c] == 0 {
-				sorted = append(sorted, c)
-				for _, child := range c.children() {
-					numParents[child]--
-				}
-				all[i] = nil
+		}
+	}
+	for _, m := range all {
+		for _, c := range m.children() {
+			if _, ok := f.set[c]; ok {
+				// the added model would have to be applied before the child already in the fixture.
+				errs = append(errs, fmt.Errorf("cannot add a parent of a model in the fixture: child %T -> parent %T", c.model(), m.model()))
 			}
 		}
 	}
-	f.connectors = sorted
-	return f
+	if len(errs) > 0 {
+		if cfg.Panic {
+			panic(errors.Join(errs...))
+		}
+		tb.Fatalf("invalid models: %v", errors.Join(errs...))
+		return
+	}
+	var warns []error
+	for _, m := range all {
+		warns = append(warns, m.warnings()...)
+	}
+	if len(warns) > 0 {
+		if cfg.Strict {
+			tb.Fatalf("invalid models in strict mode: %v", errors.Join(warns...))
+			return
+		}
+		tb.Logf("warning: %v", errors.Join(warns...))
+	}
+	for _, m := range all {
+		errs = append(errs, m.missingParents(cfg.Strict)...)
+	}
+	if len(errs) > 0 {
+		tb.Fatalf("missing parents: %v", errors.Join(errs...))
+		return
+	}
+	for _, m := range all {
+		errs = append(errs, m.ambiguousAncestors()...)
+	}
+	if len(errs) > 0 {
+		tb.Fatalf("ambiguous ancestors: %v", errors.Join(errs...))
+		return
+	}
+	var sorted []IModel
+	switch {
+	case f.order != nil:
+		sorted = f.order(all)
+	case cfg.Deterministic:
+		sorted = sortModelsInOrder(all)
+	default:
+		// 順序をあえてランダムにする
+		sorted = sortModels(all)
+	}
+	if err := cyclicError(all, sorted); err != nil {
+		tb.Fatalf("%v", err)
+		return
+	}
+	for _, m := range sorted {
+		f.connectors = append(f.connectors, m)
+		f.set[m] = struct{}{}
+	}
+}
+
+// sortModels sorts the models in a topological order.
+// The next model is chosen randomly from the models whose parents are all sorted,
+// so that the order of models without dependencies between them is random.
+// Parents not in models are ignored, and models in or below a cycle are left out.
+func sortModels(all []IModel) []IModel {
+	sorted := make([]IModel, 0, len(all))
+	for _, i := range newGraph(all).sample() {
+		sorted = append(sorted, all[i])
+	}
+	return sorted
 }
 
 // All returns all models in the fixture.
@@ -304,32 +814,39 @@ func (f *Fixture) All() []any {
 }
 
 // Apply applies visit and call connector functions in the topological order of the models.
+// Connectors of a model are called right before the model is visited,
+// except for backward connectors, which are called right after the model is visited.
+// The parents updated by backward connectors are visited again after all models are visited.
+// Models visited by a previous Apply are skipped.
+//
+// Apply(visit) is equivalent to ApplyPhases(Phase{Visit: visit}).
 func (f *Fixture) Apply(visit func(model any) error) {
 	f.t.Helper()
-	for _, c := range f.connectors {
-		if err := visit(c.model()); err != nil {
-			f.t.Fatalf("failed to visit %v: %v", c.model(), err)
-		}
-		for _, child := range c.children() {
-			labels := c.labels(child)
-			for _, connect := range child.connectors() {
-				for _, label := range labels {
-					connect(f.t, c.model(), label)
-				}
-			}
-		}
-	}
+	f.ApplyPhases(Phase{Visit: visit})
 }
 
-// collect collects all models that are connected to each other.
-func collect(fixtures []IModel) []IModel {
+// collect collects all models that are connected to each other in their declaration order,
+// which is the depth-first order from fixtures through children and then parents in the order they are registered.
+// It returns errors for the lazy models in fixtures which cannot be resolved.
+func collect(fixtures []IModel) ([]IModel, []error) {
 	set := make(map[IModel]struct{}, len(fixtures))
+	var collected []IModel
+	var errs []error
 	var visit func(c IModel)
 	visit = func(c IModel) {
+		if l, ok := c.(*lazyModel); ok {
+			var err error
+			if c, err = l.resolve(); err != nil {
+				errs = append(errs, err)
+				return
+			}
+		}
 		if _, ok := set[c]; ok {
 			return
 		}
 		set[c] = struct{}{}
+		collected = append(collected, c)
+		c.resolveLazy()
 		for _, child := range c.children() {
 			visit(child)
 		}
@@ -340,13 +857,5 @@ func collect(fixtures []IModel) []IModel {
 	for _, c := range fixtures {
 		visit(c)
 	}
-	return keys(set)
-}
-
-func keys[U comparable, V any](m map[U]V) []U {
-	ks := make([]U, 0, len(m))
-	for k := range m {
-		ks = append(ks, k)
-	}
-	return ks
+	return collected, errs
 }
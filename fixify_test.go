@@ -3,6 +3,10 @@ package fixify_test
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/qawatake/fixify"
@@ -103,9 +107,9 @@ func TestModel_With(t *testing.T) {
 	t.Run("try to connect to non-parent", func(t *testing.T) {
 		t.Parallel()
 		book := Book()
-		assert.PanicsWithError(t, "cannot connect: child *model.Library -> parent *model.Book", func() {
-			book.With(Library())
-		})
+		assertInvalidModels(t, "cannot connect: child *model.Library -> parent *model.Book",
+			book.With(Library()),
+		)
 	})
 
 	t.Run("cyclic", func(t *testing.T) {
@@ -113,9 +117,9 @@ func TestModel_With(t *testing.T) {
 		library := Library()
 		book := Book()
 		library.With(book)
-		assert.PanicsWithError(t, "cyclic dependency: *model.Book <-> *model.Library", func() {
-			book.With(library)
-		})
+		assertInvalidModels(t, "cyclic dependency: *model.Book <-> *model.Library",
+			book.With(library),
+		)
 	})
 }
 
@@ -182,39 +186,57 @@ func TestModel_WithParentAs(t *testing.T) {
 	t.Run("cyclic", func(t *testing.T) {
 		t.Parallel()
 		var c *fixify.Model[model.Cyclic]
-		assert.PanicsWithError(t, "cyclic dependency: *model.Cyclic <-> *model.Cyclic", func() {
+		assertInvalidModels(t, "cyclic dependency: *model.Cyclic <-> *model.Cyclic",
 			Cyclic().With(
 				Cyclic().Bind(&c),
-			).WithParentAs(nil, c)
-		})
+			).WithParentAs(nil, c),
+		)
 	})
 
 	t.Run("try to connect to non-parent", func(t *testing.T) {
 		t.Parallel()
-		assert.PanicsWithError(t, "cannot connect: child *model.Follow -> parent *model.Library", func() {
-			Follow().WithParentAs("follower", Library())
-		})
+		assertInvalidModels(t, "cannot connect: child *model.Follow -> parent *model.Library",
+			Follow().WithParentAs("follower", Library()),
+		)
 	})
 
 	t.Run("not support label but given", func(t *testing.T) {
 		t.Parallel()
-		assert.PanicsWithError(t, "cannot connect: child *model.Book -> parent *model.Library", func() {
-			Book().WithParentAs("label", Library())
-		})
+		assertInvalidModels(t, `cannot connect: child *model.Book -> parent *model.Library with label "label" (accepted: no label)`,
+			Book().WithParentAs("label", Library()),
+		)
 	})
 
 	t.Run("unknown label value", func(t *testing.T) {
 		t.Parallel()
-		assert.PanicsWithError(t, "cannot connect: child *model.Follow -> parent *model.User", func() {
-			Follow().WithParentAs("unknown", User("bob"))
-		})
+		assertInvalidModels(t, "cannot connect: child *model.Follow -> parent *model.User with label \"unknown\" (accepted: \"follower\", \"followee\")",
+			Follow().WithParentAs("unknown", User("bob")),
+		)
 	})
 
 	t.Run("unknown label type", func(t *testing.T) {
 		t.Parallel()
-		assert.PanicsWithError(t, "cannot connect: child *model.Follow -> parent *model.User", func() {
-			Follow().WithParentAs(1, User("bob"))
-		})
+		assertInvalidModels(t, "cannot connect: child *model.Follow -> parent *model.User with label 1 (accepted: \"follower\", \"followee\")",
+			Follow().WithParentAs(1, User("bob")),
+		)
+	})
+
+	t.Run("only parent passed to New", func(t *testing.T) {
+		t.Parallel()
+		user := User("bob")
+		Follow().WithParentAs("unknown", user)
+		assertInvalidModels(t, "cannot connect: child *model.Follow -> parent *model.User with label \"unknown\" (accepted: \"follower\", \"followee\")",
+			user,
+		)
+	})
+
+	t.Run("only parent passed to New without label support", func(t *testing.T) {
+		t.Parallel()
+		library := Library()
+		Book().WithParentAs("label", library)
+		assertInvalidModels(t, `cannot connect: child *model.Book -> parent *model.Library with label "label" (accepted: no label)`,
+			library,
+		)
 	})
 }
 
@@ -366,6 +388,177 @@ func TestFixture_Apply(t *testing.T) {
 	})
 }
 
+func TestNew_construction_errors(t *testing.T) {
+	t.Parallel()
+	t.Run("location", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		_, file, line, _ := runtime.Caller(0)
+		book := Book().With(Library())
+		fixify.New(dt, book)
+		want := fmt.Sprintf("invalid models: %s:%d: cannot connect: child *model.Library -> parent *model.Book", filepath.Base(file), line+1)
+		assert.Equal(t, []string{want}, dt.messages)
+	})
+
+	t.Run("multiple errors", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.New(dt,
+			Book().With(Library()),
+			Follow().WithParentAs("unknown", User("bob")),
+		)
+		assert.Len(t, dt.messages, 1)
+		assert.Len(t, strings.Split(dt.messages[0], "\n"), 2)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+		assert.Panics(t, func() {
+			fixify.Config{Panic: true}.New(t, Book().With(Library()))
+		})
+	})
+}
+
+func TestNewModel_ambiguous_connectors(t *testing.T) {
+	t.Parallel()
+	// ambiguousBook has two connectors for a library.
+	ambiguousBook := func() *fixify.Model[model.Book] {
+		return fixify.NewModel(new(model.Book),
+			fixify.ConnectorFunc(func(_ testing.TB, book *model.Book, library *model.Library) {
+				book.LibraryID = library.ID
+			}),
+			fixify.ConnectorFunc(func(_ testing.TB, book *model.Book, _ *model.Library) {
+				book.Name = "book"
+			}),
+		)
+	}
+	want := "ambiguous connectors: *model.Book has multiple connectors for parent *model.Library"
+
+	t.Run("warning", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		f := fixify.New(dt, ambiguousBook())
+		assert.Equal(t, 0, dt.countFatalf)
+		assert.Len(t, f.All(), 1)
+		if assert.Len(t, dt.logs, 1) {
+			assert.Regexp(t, `^warning: fixify_test\.go:\d+: `+regexp.QuoteMeta(want)+`$`, dt.logs[0])
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		f := fixify.Config{Strict: true}.New(dt, ambiguousBook())
+		assert.Empty(t, f.All())
+		if assert.Len(t, dt.messages, 1) {
+			assert.Regexp(t, `^invalid models in strict mode: fixify_test\.go:\d+: `+regexp.QuoteMeta(want)+`$`, dt.messages[0])
+		}
+	})
+
+	t.Run("different labels and kinds", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		f := fixify.Config{Strict: true}.New(dt,
+			Ticket().WithParentAs("assignee", User("alice")),
+			Company().With(
+				Department("finance").With(
+					Manager(),
+				),
+			),
+		)
+		assert.Empty(t, dt.logs)
+		assert.Empty(t, dt.messages)
+		assert.Len(t, f.All(), 5)
+	})
+}
+
+func TestModel_duplicate_edges(t *testing.T) {
+	t.Parallel()
+	t.Run("With", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		book := Book()
+		fixify.New(dt, Library().With(book, book))
+		assert.Equal(t, 0, dt.countFatalf)
+		if assert.Len(t, dt.logs, 1) {
+			assert.Regexp(t, `^warning: fixify_test\.go:\d+: duplicate edge: child \*model\.Book -> parent \*model\.Library$`, dt.logs[0])
+		}
+	})
+
+	t.Run("WithParentAs", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		var user *fixify.Model[model.User]
+		fixify.Config{Strict: true}.New(dt,
+			Follow().
+				WithParentAs("follower", User("alice").Bind(&user)).
+				WithParentAs("followee", User("bob")).
+				WithParentAs("follower", user),
+		)
+		if assert.Len(t, dt.messages, 1) {
+			assert.Regexp(t, `^invalid models in strict mode: fixify_test\.go:\d+: duplicate edge: child \*model\.Follow -> parent \*model\.User with label "follower"$`, dt.messages[0])
+		}
+	})
+
+	t.Run("same parent with different labels", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		var user *fixify.Model[model.User]
+		fixify.New(dt,
+			Follow().
+				WithParentAs("follower", User("alice").Bind(&user)).
+				WithParentAs("followee", user),
+		)
+		assert.Empty(t, dt.logs)
+	})
+}
+
+func TestConnectorFuncE(t *testing.T) {
+	t.Parallel()
+	// validatedBook fails to connect to a library without ID.
+	validatedBook := func() *fixify.Model[model.Book] {
+		return fixify.NewModel(new(model.Book),
+			fixify.ConnectorFuncE(func(book *model.Book, library *model.Library) error {
+				if library.ID == 0 {
+					return errors.New("library has no ID")
+				}
+				book.LibraryID = library.ID
+				return nil
+			}),
+		)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.New(t,
+			Library().With(
+				validatedBook(),
+			),
+		)
+		f.Apply(func(v any) error {
+			if v, ok := v.(*model.Library); ok {
+				v.ID = 1
+			}
+			return nil
+		})
+		assert.Equal(t, []*model.Book{{LibraryID: 1}}, filter[*model.Book](f.All()))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		f := fixify.New(dt,
+			Library().With(
+				validatedBook(),
+			),
+		)
+		f.Apply(func(_ any) error {
+			return nil
+		})
+		assert.Equal(t, []string{"failed to connect: child *model.Book -> parent *model.Library: library has no ID"}, dt.messages)
+	})
+}
+
 // Book represents a fixture for the book model.
 func Book() *fixify.Model[model.Book] {
 	return fixify.NewModel(
@@ -437,11 +630,29 @@ func Cyclic() *fixify.Model[model.Cyclic] {
 	)
 }
 
+// assertInvalidModels asserts that New reports the error occurred in constructing models with its location.
+func assertInvalidModels(t *testing.T, want string, models ...fixify.IModel) {
+	t.Helper()
+	dt := &dummyTestReporter{TB: t}
+	f := fixify.New(dt, models...)
+	assert.Empty(t, f.All())
+	if assert.Len(t, dt.messages, 1) {
+		assert.Regexp(t, `^invalid models: \w+_test\.go:\d+: `+regexp.QuoteMeta(want)+`$`, dt.messages[0])
+	}
+}
+
 type dummyTestReporter struct {
 	testing.TB
 	countFatalf int
+	messages    []string
+	logs        []string
+}
+
+func (d *dummyTestReporter) Logf(format string, args ...interface{}) {
+	d.logs = append(d.logs, fmt.Sprintf(format, args...))
 }
 
-func (d *dummyTestReporter) Fatalf(_ string, _ ...interface{}) {
+func (d *dummyTestReporter) Fatalf(format string, args ...interface{}) {
 	d.countFatalf++
+	d.messages = append(d.messages, fmt.Sprintf(format, args...))
 }
@@ -0,0 +1,207 @@
+package fixifylint
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// checkApply checks that the type switches of the visitors passed to Apply or ApplyPhases handle all model types in the fixture.
+func (c *checker) checkApply(call *ast.CallExpr) {
+	recv := call.Fun.(*ast.SelectorExpr).X
+	models, ok := c.fixtureModels(recv)
+	if !ok || len(models) == 0 {
+		return
+	}
+	for _, visit := range c.visitors(call) {
+		c.checkVisitor(visit, models)
+	}
+}
+
+// fixtureModels returns the model types in the fixture created by New or Config.New and extended by Add.
+// Models which are not found statically, e.g. models created by Config.Factories, are not included.
+func (c *checker) fixtureModels(e ast.Expr) ([]types.Type, bool) {
+	var models []types.Type
+	var exprs []ast.Expr
+	e = ast.Unparen(e)
+loop:
+	for {
+		if rhs, ok := c.assigned(e); ok {
+			// the fixture may be extended by Add afterward.
+			exprs = append(exprs, c.addedModels(e)...)
+			e = ast.Unparen(rhs)
+			continue
+		}
+		call, ok := e.(*ast.CallExpr)
+		if !ok {
+			return nil, false
+		}
+		fn := c.callee(call)
+		switch {
+		case isFunc(fn, "New"), isMethod(fn, "Config", "New"):
+			if !call.Ellipsis.IsValid() {
+				exprs = append(exprs, call.Args[1:]...)
+			}
+			break loop
+		case isMethod(fn, "Fixture", "Add"):
+			exprs = append(exprs, call.Args...)
+			e = ast.Unparen(call.Fun.(*ast.SelectorExpr).X)
+			continue
+		default:
+			return nil, false
+		}
+	}
+	for _, e := range exprs {
+		ast.Inspect(e, func(n ast.Node) bool {
+			expr, ok := n.(ast.Expr)
+			if !ok {
+				return true
+			}
+			t := c.pass.TypesInfo.TypeOf(expr)
+			if s, ok := t.(*types.Slice); ok {
+				t = s.Elem()
+			}
+			if typ, ok := modelType(t); ok && !slices.ContainsFunc(models, func(m types.Type) bool { return types.Identical(m, typ) }) {
+				models = append(models, typ)
+			}
+			return true
+		})
+	}
+	return models, true
+}
+
+// addedModels returns the models passed to Add called on the fixture held by the variable.
+func (c *checker) addedModels(e ast.Expr) []ast.Expr {
+	id, ok := e.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	obj := c.pass.TypesInfo.Uses[id]
+	var exprs []ast.Expr
+	c.ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if !isMethod(c.callee(call), "Fixture", "Add") {
+			return
+		}
+		if recv, ok := ast.Unparen(call.Fun.(*ast.SelectorExpr).X).(*ast.Ident); ok && c.pass.TypesInfo.Uses[recv] == obj {
+			exprs = append(exprs, call.Args...)
+		}
+	})
+	return exprs
+}
+
+// visitors returns the visitors passed to Apply or ApplyPhases.
+func (c *checker) visitors(call *ast.CallExpr) []ast.Expr {
+	if isMethod(c.callee(call), "Fixture", "Apply") {
+		return call.Args
+	}
+	var visitors []ast.Expr
+	for _, arg := range call.Args {
+		lit, ok := ast.Unparen(arg).(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Visit" {
+					visitors = append(visitors, kv.Value)
+				}
+			}
+		}
+	}
+	return visitors
+}
+
+// checkVisitor reports the type switches on the argument of the visitor which miss some of the models.
+// Visitors without type switches and type switches with default cases are not checked.
+func (c *checker) checkVisitor(visit ast.Expr, models []types.Type) {
+	params, body := c.funcOf(visit)
+	if body == nil || params.NumFields() != 1 || len(params.List[0].Names) != 1 {
+		return
+	}
+	param := c.pass.TypesInfo.Defs[params.List[0].Names[0]]
+	if param == nil {
+		return
+	}
+	var switches []*ast.TypeSwitchStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		if ts, ok := n.(*ast.TypeSwitchStmt); ok && c.switchesOn(ts, param) {
+			switches = append(switches, ts)
+		}
+		return true
+	})
+	if len(switches) == 0 {
+		return
+	}
+	var cases []types.Type
+	for _, ts := range switches {
+		for _, stmt := range ts.Body.List {
+			clause := stmt.(*ast.CaseClause)
+			if clause.List == nil {
+				// the default case handles all models.
+				return
+			}
+			for _, e := range clause.List {
+				if t := c.pass.TypesInfo.TypeOf(e); t != nil {
+					cases = append(cases, t)
+				}
+			}
+		}
+	}
+	var missing []string
+	for _, m := range models {
+		ptr := types.NewPointer(m)
+		if !slices.ContainsFunc(cases, func(t types.Type) bool {
+			if iface, ok := t.Underlying().(*types.Interface); ok {
+				return types.Implements(ptr, iface)
+			}
+			return types.Identical(t, ptr)
+		}) {
+			missing = append(missing, typeName(m))
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		c.pass.Reportf(switches[0].Pos(), "type switch of the visitor misses %s in the fixture", strings.Join(missing, ", "))
+	}
+}
+
+// funcOf returns the parameters and the body of the function literal or the function declared in the package.
+func (c *checker) funcOf(e ast.Expr) (*ast.FieldList, *ast.BlockStmt) {
+	e = ast.Unparen(e)
+	if rhs, ok := c.assigned(e); ok {
+		e = ast.Unparen(rhs)
+	}
+	switch e := e.(type) {
+	case *ast.FuncLit:
+		return e.Type.Params, e.Body
+	case *ast.Ident:
+		if fn, ok := c.pass.TypesInfo.Uses[e].(*types.Func); ok {
+			if decl, ok := c.funcs[fn]; ok {
+				return decl.Type.Params, decl.Body
+			}
+		}
+	}
+	return nil, nil
+}
+
+// switchesOn reports whether the type switch is on the variable, e.g. switch v := model.(type).
+func (c *checker) switchesOn(ts *ast.TypeSwitchStmt, v types.Object) bool {
+	var x ast.Expr
+	switch s := ts.Assign.(type) {
+	case *ast.AssignStmt:
+		x = s.Rhs[0]
+	case *ast.ExprStmt:
+		x = s.X
+	}
+	ta, ok := ast.Unparen(x).(*ast.TypeAssertExpr)
+	if !ok {
+		return false
+	}
+	id, ok := ast.Unparen(ta.X).(*ast.Ident)
+	return ok && c.pass.TypesInfo.Uses[id] == v
+}
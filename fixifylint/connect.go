@@ -0,0 +1,357 @@
+package fixifylint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// factoryFact is the parents accepted by the connectors of the model returned by a factory function.
+type factoryFact struct {
+	// Model is the key of the type of the model.
+	Model   string
+	Accepts []accept
+	// Unknown is true if the factory function may return a model with connectors not created by fixify.
+	Unknown bool
+}
+
+func (*factoryFact) AFact() {}
+
+func (f *factoryFact) String() string {
+	if f.Unknown {
+		return fmt.Sprintf("factory(%s: unknown)", f.Model)
+	}
+	accepts := make([]string, 0, len(f.Accepts))
+	for _, a := range f.Accepts {
+		if a.Label.Type == "" {
+			accepts = append(accepts, a.Parent)
+		} else {
+			accepts = append(accepts, a.Parent+" "+a.Label.Value)
+		}
+	}
+	return fmt.Sprintf("factory(%s: %s)", f.Model, strings.Join(accepts, ", "))
+}
+
+// accept is a parent accepted by a connector.
+type accept struct {
+	// Parent is the key of the type of the parent.
+	Parent string
+	Label  label
+}
+
+// label is a constant label of a connector.
+type label struct {
+	// Type is the key of the type of the label. It is empty if the connector has no label.
+	Type string
+	// Value is the exact representation of the value, e.g. "follower".
+	Value string
+}
+
+func (l label) String() string {
+	if l.Type == "" {
+		return "no label"
+	}
+	return l.Value
+}
+
+// exportFactoryFacts exports the facts of the factory functions declared in the package.
+func (c *checker) exportFactoryFacts() {
+	for fn := range c.funcs {
+		if f, ok := c.factory(fn); ok && f != nil {
+			c.pass.ExportObjectFact(fn, f)
+		}
+	}
+}
+
+// factory returns the fact of the factory function.
+// It returns false if fn is not a factory function, which returns a single *fixify.Model.
+func (c *checker) factory(fn *types.Func) (*factoryFact, bool) {
+	fn = fn.Origin()
+	if f, ok := c.factories[fn]; ok {
+		return f, f != nil
+	}
+	decl, ok := c.funcs[fn]
+	if !ok {
+		f := new(factoryFact)
+		if c.pass.ImportObjectFact(fn, f) {
+			return f, true
+		}
+		return nil, false
+	}
+	// mark it in progress for recursive factories.
+	c.factories[fn] = nil
+	f := c.factoryOfDecl(fn, decl)
+	c.factories[fn] = f
+	return f, f != nil
+}
+
+func (c *checker) factoryOfDecl(fn *types.Func, decl *ast.FuncDecl) *factoryFact {
+	sig := fn.Type().(*types.Signature)
+	if decl.Body == nil || sig.TypeParams().Len() > 0 || sig.Results().Len() != 1 {
+		return nil
+	}
+	typ, ok := modelType(sig.Results().At(0).Type())
+	if !ok {
+		return nil
+	}
+	f := &factoryFact{Model: typeKey(typ)}
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) != 1 {
+				f.Unknown = true
+				return false
+			}
+			r, ok := c.traceModel(n.Results[0], make(map[ast.Expr]bool))
+			if !ok || r.Unknown {
+				f.Unknown = true
+				return false
+			}
+			f.Accepts = appendAccepts(f.Accepts, r.Accepts...)
+		}
+		return true
+	})
+	return f
+}
+
+// accepts returns the parents accepted by the model of the expression.
+// It returns false if they are unknown.
+func (c *checker) accepts(e ast.Expr) ([]accept, bool) {
+	if f, ok := c.traceModel(e, make(map[ast.Expr]bool)); ok {
+		return f.Accepts, !f.Unknown
+	}
+	typ, ok := modelType(c.pass.TypesInfo.TypeOf(e))
+	if !ok {
+		return nil, false
+	}
+	return c.acceptsOfType(typ)
+}
+
+// traceModel traces the expression back to the NewModel call or the factory function creating the model.
+func (c *checker) traceModel(e ast.Expr, seen map[ast.Expr]bool) (*factoryFact, bool) {
+	e = ast.Unparen(e)
+	if seen[e] {
+		return nil, false
+	}
+	seen[e] = true
+	if rhs, ok := c.assigned(e); ok {
+		return c.traceModel(rhs, seen)
+	}
+	call, ok := e.(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	fn := c.callee(call)
+	switch {
+	case fn == nil:
+		return nil, false
+	case isFunc(fn, "NewModel"):
+		return c.newModel(call), true
+	case isMethod(fn, "Model", "With"), isMethod(fn, "Model", "WithParent"), isMethod(fn, "Model", "WithParentAs"), isMethod(fn, "Model", "Bind"):
+		// they return the receiver.
+		return c.traceModel(call.Fun.(*ast.SelectorExpr).X, seen)
+	}
+	return c.factory(fn)
+}
+
+// newModel returns the parents accepted by the model created by the NewModel call.
+func (c *checker) newModel(call *ast.CallExpr) *factoryFact {
+	f := new(factoryFact)
+	if typ, ok := modelType(c.pass.TypesInfo.TypeOf(call)); ok {
+		f.Model = typeKey(typ)
+	}
+	if call.Ellipsis.IsValid() {
+		f.Unknown = true
+		return f
+	}
+	for _, arg := range call.Args[1:] {
+		accepts, ok := c.connectorAccepts(arg, make(map[ast.Expr]bool))
+		if !ok {
+			f.Unknown = true
+			return f
+		}
+		f.Accepts = appendAccepts(f.Accepts, accepts...)
+	}
+	return f
+}
+
+// connectorAccepts returns the parents accepted by the connector.
+// It returns false if the connector is not created by the connector functions of fixify.
+func (c *checker) connectorAccepts(e ast.Expr, seen map[ast.Expr]bool) ([]accept, bool) {
+	e = ast.Unparen(e)
+	if seen[e] {
+		return nil, false
+	}
+	seen[e] = true
+	if rhs, ok := c.assigned(e); ok {
+		return c.connectorAccepts(rhs, seen)
+	}
+	call, ok := e.(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	fn := c.callee(call)
+	targs := c.typeArgs(call)
+	switch {
+	case isFunc(fn, "Required"), isFunc(fn, "Optional"):
+		return c.connectorAccepts(call.Args[0], seen)
+	case isFunc(fn, "AncestorConnector"):
+		// an ancestor connector does not accept a parent directly.
+		return nil, true
+	case len(targs) < 2:
+		return nil, false
+	case isFunc(fn, "ConnectorFunc"), isFunc(fn, "ConnectorFuncE"), isFunc(fn, "BackConnectorFunc"), isFunc(fn, "EdgeFunc"):
+		return []accept{{Parent: typeKey(targs[1])}}, true
+	case isFunc(fn, "ConnectorFuncWithLabel"), isFunc(fn, "EdgeFuncWithLabel"):
+		l, ok := c.label(call.Args[0])
+		return []accept{{Parent: typeKey(targs[1]), Label: l}}, ok
+	case isFunc(fn, "ConnectorFunc2") && len(targs) == 3:
+		return []accept{{Parent: typeKey(targs[1])}, {Parent: typeKey(targs[2])}}, true
+	case isFunc(fn, "ConnectorFunc2WithLabels") && len(targs) == 5:
+		la, okA := c.label(call.Args[0])
+		lb, okB := c.label(call.Args[1])
+		return []accept{{Parent: typeKey(targs[1]), Label: la}, {Parent: typeKey(targs[2]), Label: lb}}, okA && okB
+	}
+	return nil, false
+}
+
+// label returns the label given by the expression.
+// It returns false if the label is not a constant.
+func (c *checker) label(e ast.Expr) (label, bool) {
+	tv, ok := c.pass.TypesInfo.Types[e]
+	switch {
+	case !ok:
+		return label{}, false
+	case tv.IsNil():
+		return label{}, true
+	case tv.Value == nil:
+		return label{}, false
+	}
+	return label{Type: typeKey(tv.Type), Value: tv.Value.ExactString()}, true
+}
+
+// acceptsOfType returns the parents accepted by the models of the type created in the package and its dependencies.
+// It returns false if some of them are unknown.
+func (c *checker) acceptsOfType(typ types.Type) ([]accept, bool) {
+	key := typeKey(typ)
+	var found bool
+	var accepts []accept
+	add := func(f *factoryFact) bool {
+		if f == nil || f.Model != key {
+			return true
+		}
+		found = true
+		accepts = appendAccepts(accepts, f.Accepts...)
+		return !f.Unknown
+	}
+	for _, f := range c.factories {
+		if !add(f) {
+			return nil, false
+		}
+	}
+	for _, of := range c.pass.AllObjectFacts() {
+		if f, ok := of.Fact.(*factoryFact); ok && !add(f) {
+			return nil, false
+		}
+	}
+	// models created without factory functions.
+	known := true
+	c.ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		if call := n.(*ast.CallExpr); known && isFunc(c.callee(call), "NewModel") {
+			known = add(c.newModel(call))
+		}
+	})
+	return accepts, found && known
+}
+
+func appendAccepts(accepts []accept, more ...accept) []accept {
+	for _, a := range more {
+		if !containsAccept(accepts, a) {
+			accepts = append(accepts, a)
+		}
+	}
+	return accepts
+}
+
+func containsAccept(accepts []accept, a accept) bool {
+	for _, b := range accepts {
+		if a == b {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWith checks that each child passed to With accepts the receiver as a parent with no label.
+func (c *checker) checkWith(call *ast.CallExpr) {
+	if call.Ellipsis.IsValid() {
+		return
+	}
+	parent, ok := modelType(c.pass.TypesInfo.TypeOf(call.Fun.(*ast.SelectorExpr).X))
+	if !ok {
+		return
+	}
+	for _, arg := range call.Args {
+		child, ok := modelType(c.pass.TypesInfo.TypeOf(arg))
+		if !ok {
+			continue
+		}
+		accepts, ok := c.accepts(arg)
+		if !ok {
+			continue
+		}
+		c.checkConnect(arg, accepts, child, parent, label{})
+	}
+}
+
+// checkWithParent checks that the receiver accepts the parent with the label.
+// labelExpr is nil for WithParent.
+func (c *checker) checkWithParent(call *ast.CallExpr, labelExpr, parentExpr ast.Expr) {
+	recv := call.Fun.(*ast.SelectorExpr).X
+	child, ok := modelType(c.pass.TypesInfo.TypeOf(recv))
+	if !ok {
+		return
+	}
+	parent, ok := modelType(c.pass.TypesInfo.TypeOf(parentExpr))
+	if !ok {
+		return
+	}
+	var l label
+	if labelExpr != nil {
+		if l, ok = c.label(labelExpr); !ok {
+			return
+		}
+	}
+	accepts, ok := c.accepts(recv)
+	if !ok {
+		return
+	}
+	c.checkConnect(parentExpr, accepts, child, parent, l)
+}
+
+// checkConnect reports the expression if none of accepts accepts the parent with the label.
+// The message is the same as the error reported by fixify at runtime.
+func (c *checker) checkConnect(at ast.Expr, accepts []accept, child, parent types.Type, l label) {
+	var labels []string
+	for _, a := range accepts {
+		if a.Parent != typeKey(parent) {
+			continue
+		}
+		if a.Label == l {
+			return
+		}
+		labels = append(labels, a.Label.String())
+	}
+	if len(labels) == 0 {
+		c.pass.Reportf(at.Pos(), "cannot connect: child %s -> parent %s", typeName(child), typeName(parent))
+		return
+	}
+	given := "no label"
+	if l.Type != "" {
+		given = "label " + l.Value
+	}
+	c.pass.Reportf(at.Pos(), "cannot connect: child %s -> parent %s with %s (accepted: %s)", typeName(child), typeName(parent), given, strings.Join(labels, ", "))
+}
@@ -0,0 +1,203 @@
+// Package fixifylint provides an analyzer which reports misuses of fixify detected statically.
+//
+// It reports
+//   - calls of With, WithParent and WithParentAs connecting models which the connectors declared in NewModel do not accept.
+//   - visitors passed to Apply and ApplyPhases whose type switches miss model types in the fixture.
+//
+// The connectors of a model are found in the NewModel call returning it,
+// either directly or through a factory function such as func Book() *fixify.Model[model.Book],
+// which may be declared in another package.
+// Models whose connectors are not created by the connector functions of fixify are not checked.
+package fixifylint
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const fixifyPath = "github.com/qawatake/fixify"
+
+// Analyzer reports misuses of fixify.
+var Analyzer = &analysis.Analyzer{
+	Name:      "fixifylint",
+	Doc:       "reports models which cannot be connected and visitors missing model types in fixify",
+	URL:       "https://pkg.go.dev/github.com/qawatake/fixify/fixifylint",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(factoryFact)},
+	Run:       run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	c := newChecker(pass, ins)
+	c.exportFactoryFacts()
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		switch fn := c.callee(call); {
+		case isMethod(fn, "Model", "With"):
+			c.checkWith(call)
+		case isMethod(fn, "Model", "WithParent"):
+			c.checkWithParent(call, nil, call.Args[0])
+		case isMethod(fn, "Model", "WithParentAs"):
+			c.checkWithParent(call, call.Args[0], call.Args[1])
+		case isMethod(fn, "Fixture", "Apply"), isMethod(fn, "Fixture", "ApplyPhases"):
+			c.checkApply(call)
+		}
+	})
+	return nil, nil
+}
+
+type checker struct {
+	pass *analysis.Pass
+	ins  *inspector.Inspector
+	// assigns maps a variable to the expressions assigned to it.
+	assigns map[*types.Var][]ast.Expr
+	// funcs maps a function declared in the package to its declaration.
+	funcs map[*types.Func]*ast.FuncDecl
+	// factories maps a factory function declared in the package to the parents accepted by its model.
+	factories map[*types.Func]*factoryFact
+}
+
+func newChecker(pass *analysis.Pass, ins *inspector.Inspector) *checker {
+	c := &checker{
+		pass:      pass,
+		ins:       ins,
+		assigns:   make(map[*types.Var][]ast.Expr),
+		funcs:     make(map[*types.Func]*ast.FuncDecl),
+		factories: make(map[*types.Func]*factoryFact),
+	}
+	ins.Preorder([]ast.Node{(*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil), (*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return
+			}
+			for i, lhs := range n.Lhs {
+				c.addAssign(lhs, n.Rhs[i])
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) != len(n.Values) {
+				return
+			}
+			for i, name := range n.Names {
+				c.addAssign(name, n.Values[i])
+			}
+		case *ast.FuncDecl:
+			if fn, ok := pass.TypesInfo.Defs[n.Name].(*types.Func); ok {
+				c.funcs[fn] = n
+			}
+		}
+	})
+	return c
+}
+
+func (c *checker) addAssign(lhs ast.Expr, rhs ast.Expr) {
+	id, ok := ast.Unparen(lhs).(*ast.Ident)
+	if !ok {
+		return
+	}
+	if v, ok := c.pass.TypesInfo.ObjectOf(id).(*types.Var); ok {
+		c.assigns[v] = append(c.assigns[v], rhs)
+	}
+}
+
+// assigned returns the expression assigned to the variable if it is assigned only once.
+func (c *checker) assigned(e ast.Expr) (ast.Expr, bool) {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	v, ok := c.pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok || len(c.assigns[v]) != 1 {
+		return nil, false
+	}
+	return c.assigns[v][0], true
+}
+
+func (c *checker) callee(call *ast.CallExpr) *types.Func {
+	fn, _ := typeutil.Callee(c.pass.TypesInfo, call).(*types.Func)
+	return fn
+}
+
+// typeArgs returns the type arguments of the generic function called by call.
+func (c *checker) typeArgs(call *ast.CallExpr) []types.Type {
+	fun := ast.Unparen(call.Fun)
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	var id *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	default:
+		return nil
+	}
+	inst, ok := c.pass.TypesInfo.Instances[id]
+	if !ok {
+		return nil
+	}
+	args := make([]types.Type, inst.TypeArgs.Len())
+	for i := range args {
+		args[i] = inst.TypeArgs.At(i)
+	}
+	return args
+}
+
+// isFunc reports whether fn is the package-level function of fixify with the name.
+func isFunc(fn *types.Func, name string) bool {
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == fixifyPath &&
+		fn.Name() == name && fn.Type().(*types.Signature).Recv() == nil
+}
+
+// isMethod reports whether fn is the method of the type of fixify with the name.
+func isMethod(fn *types.Func, typ, name string) bool {
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != fixifyPath || fn.Name() != name {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Name() == typ
+}
+
+// modelType returns T if t is *fixify.Model[T].
+func modelType(t types.Type) (types.Type, bool) {
+	p, ok := t.(*types.Pointer)
+	if !ok {
+		return nil, false
+	}
+	named, ok := p.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != fixifyPath || named.Obj().Name() != "Model" {
+		return nil, false
+	}
+	if named.TypeArgs().Len() != 1 {
+		return nil, false
+	}
+	return named.TypeArgs().At(0), true
+}
+
+// typeName returns the name of *t in the same form as %T, e.g. *model.Book.
+func typeName(t types.Type) string {
+	return types.TypeString(types.NewPointer(t), func(p *types.Package) string { return p.Name() })
+}
+
+// typeKey returns the key of t, which is unique across packages.
+func typeKey(t types.Type) string {
+	return types.TypeString(t, nil)
+}
@@ -0,0 +1,12 @@
+package fixifylint_test
+
+import (
+	"testing"
+
+	"github.com/qawatake/fixify/fixifylint"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), fixifylint.Analyzer, "a")
+}
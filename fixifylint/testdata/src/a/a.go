@@ -0,0 +1,136 @@
+package a
+
+import (
+	"errors"
+	"testing"
+
+	"factory"
+
+	"github.com/qawatake/fixify"
+	"model"
+)
+
+type label string
+
+const followerLabel label = "follower"
+
+func Student() *fixify.Model[model.Student] { // want Student:`factory\(model.Student: \)`
+	return fixify.NewModel(new(model.Student))
+}
+
+func Classroom() *fixify.Model[model.Classroom] { // want Classroom:`factory\(model.Classroom: \)`
+	return fixify.NewModel(new(model.Classroom))
+}
+
+var followerEdge = fixify.EdgeFuncWithLabel("follower", func(_ testing.TB, follow *model.Follow, follower *model.User) {})
+
+func EdgeFollow() *fixify.Model[model.Follow] { // want EdgeFollow:`factory\(model.Follow: model.User "follower"\)`
+	return fixify.NewModel(new(model.Follow), followerEdge)
+}
+
+// LabeledFollow is a factory with a label of a named type.
+func LabeledFollow() *fixify.Model[model.Follow] { // want LabeledFollow:`factory\(model.Follow: model.User "follower", model.User "followee"\)`
+	return factory.Follow().WithParentAs("followee", factory.User())
+}
+
+func TypedFollow() *fixify.Model[model.Follow] { // want TypedFollow:`factory\(model.Follow: model.User "follower"\)`
+	return fixify.NewModel(new(model.Follow),
+		fixify.ConnectorFuncWithLabel(followerLabel, func(_ testing.TB, follow *model.Follow, follower *model.User) {}),
+	)
+}
+
+func connect() {
+	factory.Library().With(
+		factory.Book().With(
+			factory.Page(),
+			factory.Library(), // want `cannot connect: child \*model.Library -> parent \*model.Book`
+		),
+	)
+	factory.Book().With(factory.Page().With(factory.Book())) // want `cannot connect: child \*model.Book -> parent \*model.Page`
+	factory.Page().WithParent(factory.Book())
+	factory.Page().WithParent(factory.Library()) // want `cannot connect: child \*model.Page -> parent \*model.Library`
+
+	factory.Follow().WithParentAs("follower", factory.User()).WithParentAs("followee", factory.User())
+	factory.Follow().WithParentAs("unknown", factory.User()) // want `cannot connect: child \*model.Follow -> parent \*model.User with label "unknown" \(accepted: "follower", "followee"\)`
+	factory.User().With(factory.Follow())                    // want `cannot connect: child \*model.Follow -> parent \*model.User with no label \(accepted: "follower", "followee"\)`
+	LabeledFollow().WithParent(factory.User())               // want `cannot connect: child \*model.Follow -> parent \*model.User with no label`
+	TypedFollow().WithParentAs(followerLabel, factory.User())
+	TypedFollow().WithParentAs("follower", factory.User()) // want `cannot connect: child \*model.Follow -> parent \*model.User with label "follower" \(accepted: "follower"\)`
+
+	EdgeFollow().WithParentAs("followee", factory.User()) // want `cannot connect: child \*model.Follow -> parent \*model.User with label "followee" \(accepted: "follower"\)`
+
+	factory.Enrollment().WithParent(Student()).WithParent(Classroom())
+	Student().With(factory.Enrollment())
+	factory.Enrollment().WithParent(factory.User()) // want `cannot connect: child \*model.Enrollment -> parent \*model.User`
+
+	// models held by variables.
+	book := factory.Book()
+	library := factory.Library().With(book)
+	book.With(library) // want `cannot connect: child \*model.Library -> parent \*model.Book`
+	var bound *fixify.Model[model.Page]
+	factory.Book().With(factory.Page().Bind(&bound))
+	factory.Library().With(bound) // want `cannot connect: child \*model.Page -> parent \*model.Library`
+
+	// inline models.
+	fixify.NewModel(new(model.Library)).With(fixify.NewModel(new(model.Book))) // want `cannot connect: child \*model.Book -> parent \*model.Library`
+
+	// unknown connectors are not checked.
+	factory.Custom(nil).WithParent(factory.User())
+	factory.User().With(fixify.Lazy(func() fixify.IModel { return factory.Book() }))
+}
+
+func apply(t *testing.T) {
+	f := fixify.New(t, factory.Library().With(factory.Book().With(factory.Page())))
+	f.Apply(func(v any) error {
+		switch v := v.(type) { // want `type switch of the visitor misses \*model.Page in the fixture`
+		case *model.Library:
+			v.ID = 1
+		case *model.Book:
+			v.ID = 1
+		}
+		return nil
+	})
+	f.Apply(func(v any) error {
+		switch v.(type) {
+		case *model.Library, *model.Book, *model.Page:
+		}
+		return nil
+	})
+	f.Apply(func(v any) error {
+		switch v.(type) {
+		case *model.Library:
+		default:
+			return errors.New("unknown")
+		}
+		return nil
+	})
+	f.Apply(func(v any) error {
+		// visitors without type switches are not checked.
+		return nil
+	})
+	f.Apply(setID)
+
+	g := fixify.Config{Strict: true}.New(t, factory.User()).Add(factory.Follow())
+	g.ApplyPhases(fixify.Phase{Visit: func(v any) error {
+		switch v.(type) { // want `type switch of the visitor misses \*model.Follow in the fixture`
+		case interface{ unknown() }:
+		case *model.User:
+		}
+		return nil
+	}})
+
+	h := fixify.New(t, factory.User())
+	h.Add(factory.Book())
+	h.Apply(func(v any) error {
+		switch v.(type) { // want `type switch of the visitor misses \*model.Book, \*model.User in the fixture`
+		}
+		return nil
+	})
+}
+
+func setID(v any) error {
+	switch v.(type) { // want `type switch of the visitor misses \*model.Page in the fixture`
+	case *model.Library, *model.Book:
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package factory
+
+import (
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"model"
+)
+
+func Library() *fixify.Model[model.Library] {
+	return fixify.NewModel(new(model.Library))
+}
+
+func Book() *fixify.Model[model.Book] {
+	return fixify.NewModel(new(model.Book),
+		fixify.ConnectorFunc(func(_ testing.TB, book *model.Book, library *model.Library) {
+			book.LibraryID = library.ID
+		}),
+	)
+}
+
+func Page() *fixify.Model[model.Page] {
+	page := fixify.NewModel(new(model.Page),
+		fixify.Required(fixify.ConnectorFunc(func(_ testing.TB, page *model.Page, book *model.Book) {
+			page.BookID = book.ID
+		})),
+	)
+	return page
+}
+
+func User() *fixify.Model[model.User] {
+	return fixify.NewModel(new(model.User))
+}
+
+func Follow() *fixify.Model[model.Follow] {
+	return fixify.NewModel(new(model.Follow),
+		fixify.ConnectorFuncWithLabel("follower", func(_ testing.TB, follow *model.Follow, follower *model.User) {
+			follow.FollowerID = follower.ID
+		}),
+		fixify.ConnectorFuncWithLabel("followee", func(_ testing.TB, follow *model.Follow, followee *model.User) {
+			follow.FolloweeID = followee.ID
+		}),
+	)
+}
+
+func Enrollment() *fixify.Model[model.Enrollment] {
+	return fixify.NewModel(new(model.Enrollment),
+		fixify.ConnectorFunc2(func(_ testing.TB, e *model.Enrollment, s *model.Student, c *model.Classroom) {
+			e.StudentID = s.ID
+			e.ClassroomID = c.ID
+		}),
+	)
+}
+
+// Custom has a connector not created by fixify.
+func Custom(c fixify.Connecter[model.Book]) *fixify.Model[model.Book] {
+	return fixify.NewModel(new(model.Book), c)
+}
@@ -0,0 +1,92 @@
+// Package fixify is a stub of github.com/qawatake/fixify for tests.
+package fixify
+
+import "testing"
+
+type IModel interface{ model() any }
+
+type Model[T any] struct{ v *T }
+
+func (m *Model[T]) model() any { return m.v }
+
+type Connecter[T any] interface{ connect(*T) }
+
+type connecter[T any] struct{}
+
+func (connecter[T]) connect(*T) {}
+
+func NewModel[T any](model *T, connectorFuncs ...Connecter[T]) *Model[T] { return &Model[T]{v: model} }
+
+func ConnectorFunc[U, V any](f func(t testing.TB, childModel *U, parentModel *V)) Connecter[U] {
+	return connecter[U]{}
+}
+
+func ConnectorFuncE[U, V any](f func(childModel *U, parentModel *V) error) Connecter[U] {
+	return connecter[U]{}
+}
+
+func ConnectorFuncWithLabel[U, V any, L comparable](label L, f func(t testing.TB, childModel *U, parentModel *V)) Connecter[U] {
+	return connecter[U]{}
+}
+
+func BackConnectorFunc[U, V any](f func(t testing.TB, childModel *U, parentModel *V)) Connecter[U] {
+	return connecter[U]{}
+}
+
+func AncestorConnector[U, V any](f func(t testing.TB, childModel *U, ancestorModel *V)) Connecter[U] {
+	return connecter[U]{}
+}
+
+func ConnectorFunc2[U, A, B any](f func(t testing.TB, childModel *U, parentA *A, parentB *B)) Connecter[U] {
+	return connecter[U]{}
+}
+
+func ConnectorFunc2WithLabels[U, A, B any, LA, LB comparable](labelA LA, labelB LB, f func(t testing.TB, childModel *U, parentA *A, parentB *B)) Connecter[U] {
+	return connecter[U]{}
+}
+
+type Edge[C, P any] struct{ Connecter[C] }
+
+func EdgeFunc[C, P any](f func(t testing.TB, childModel *C, parentModel *P)) Edge[C, P] {
+	return Edge[C, P]{connecter[C]{}}
+}
+
+func EdgeFuncWithLabel[C, P any, L comparable](label L, f func(t testing.TB, childModel *C, parentModel *P)) Edge[C, P] {
+	return Edge[C, P]{connecter[C]{}}
+}
+
+func Required[T any](c Connecter[T]) Connecter[T] { return c }
+
+func Optional[T any](c Connecter[T]) Connecter[T] { return c }
+
+func Lazy(f func() IModel) IModel { return nil }
+
+func (m *Model[T]) With(children ...IModel) *Model[T] { return m }
+
+func (m *Model[T]) WithParent(parent IModel) *Model[T] { return m }
+
+func (m *Model[T]) WithParentAs(label any, parent IModel) *Model[T] { return m }
+
+func (m *Model[T]) Bind(b **Model[T]) *Model[T] { return m }
+
+func (m *Model[T]) Value() *T { return m.v }
+
+type Fixture struct{}
+
+func New(tb testing.TB, fixtures ...IModel) *Fixture { return nil }
+
+type Config struct{ Strict bool }
+
+func (c Config) New(tb testing.TB, fixtures ...IModel) *Fixture { return nil }
+
+func (f *Fixture) Add(models ...IModel) *Fixture { return f }
+
+func (f *Fixture) Apply(visit func(model any) error) {}
+
+type Phase struct {
+	Name          string
+	Visit         func(model any) error
+	BeforeConnect bool
+}
+
+func (f *Fixture) ApplyPhases(phases ...Phase) {}
@@ -0,0 +1,29 @@
+package model
+
+type Library struct{ ID int64 }
+
+type Book struct {
+	ID        int64
+	LibraryID int64
+}
+
+type Page struct {
+	ID     int64
+	BookID int64
+}
+
+type User struct{ ID int64 }
+
+type Follow struct {
+	FollowerID int64
+	FolloweeID int64
+}
+
+type Enrollment struct {
+	StudentID   int64
+	ClassroomID int64
+}
+
+type Student struct{ ID int64 }
+
+type Classroom struct{ ID int64 }
@@ -0,0 +1,108 @@
+package fixify
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Connection is an edge between models in a fixture.
+type Connection struct {
+	Parent any
+	Child  any
+	// Label is the label with which the child is connected to the parent, or nil if it has no label.
+	Label any
+}
+
+// Roots returns the models in the fixture which have no parents in the fixture, in the order of [Fixture.All].
+func (f *Fixture) Roots() []any {
+	var roots []any
+	for _, c := range f.connectors {
+		if !slices.ContainsFunc(c.parents(), f.contains) {
+			roots = append(roots, c.model())
+		}
+	}
+	return roots
+}
+
+// Leaves returns the models in the fixture which have no children in the fixture, in the order of [Fixture.All].
+func (f *Fixture) Leaves() []any {
+	var leaves []any
+	for _, c := range f.connectors {
+		if !slices.ContainsFunc(c.children(), f.contains) {
+			leaves = append(leaves, c.model())
+		}
+	}
+	return leaves
+}
+
+// Levels returns the models in the fixture grouped by their depths, in the order of [Fixture.All] in each group.
+// The depth of a model is the length of the longest path from a root to the model,
+// so that a model is placed after all of its parents.
+func (f *Fixture) Levels() [][]any {
+	depths := make(map[IModel]int, len(f.connectors))
+	var depth func(m IModel) int
+	depth = func(m IModel) int {
+		if d, ok := depths[m]; ok {
+			return d
+		}
+		d := 0
+		for _, p := range m.parents() {
+			if f.contains(p) {
+				d = max(d, depth(p)+1)
+			}
+		}
+		depths[m] = d
+		return d
+	}
+	var levels [][]any
+	for _, c := range f.connectors {
+		d := depth(c)
+		for len(levels) <= d {
+			levels = append(levels, nil)
+		}
+		levels[d] = append(levels[d], c.model())
+	}
+	return levels
+}
+
+// Edges returns the connections between the models in the fixture.
+// They are ordered by the children in the order of [Fixture.All] and then by the parents in the order they are registered.
+// A child connected to the same parent with multiple labels has a connection for each label.
+// Models connected by ancestor connectors are not included, because they are not connected directly.
+func (f *Fixture) Edges() []Connection {
+	var edges []Connection
+	for _, c := range f.connectors {
+		for _, p := range c.parents() {
+			if !f.contains(p) {
+				continue
+			}
+			for _, label := range sortLabels(p.labels(c)) {
+				edges = append(edges, Connection{Parent: p.model(), Child: c.model(), Label: label})
+			}
+		}
+	}
+	return edges
+}
+
+// contains reports whether the model is in the fixture.
+func (f *Fixture) contains(m IModel) bool {
+	_, ok := f.set[m]
+	return ok
+}
+
+// sortLabels sorts the labels by their string representations, with nil first.
+func sortLabels(labels []any) []any {
+	slices.SortFunc(labels, func(a, b any) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		}
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+	return labels
+}
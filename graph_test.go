@@ -0,0 +1,143 @@
+package fixify_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleFixture_Levels() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	f := fixify.Config{Deterministic: true}.New(t,
+		Company().With(
+			Department("finance").With(
+				Employee(),
+			),
+			Department("sales"),
+		),
+	)
+	for i, level := range f.Levels() {
+		fmt.Printf("%d: %s\n", i, modelNames(level))
+	}
+	// Output:
+	// 0: company
+	// 1: finance sales
+	// 2: employee
+}
+
+func ExampleFixture_Edges() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	f := fixify.Config{Deterministic: true}.New(t,
+		Follow().
+			WithParentAs("follower", User("alice")).
+			WithParentAs("followee", User("bob")),
+	)
+	for _, e := range f.Edges() {
+		fmt.Printf("%s -> %T (%v)\n", e.Parent.(*model.User).Name, e.Child, e.Label)
+	}
+	// Output:
+	// alice -> *model.Follow (follower)
+	// bob -> *model.Follow (followee)
+}
+
+func TestFixture_Roots(t *testing.T) {
+	t.Parallel()
+	company := Company()
+	f := fixify.Config{Deterministic: true}.New(t,
+		company.With(
+			Department("finance").With(Employee()),
+			Department("sales"),
+		),
+		User("alice"),
+	)
+	roots := f.Roots()
+	if assert.Len(t, roots, 2) {
+		assert.Same(t, company.Value(), roots[0])
+		assert.IsType(t, &model.User{}, roots[1])
+	}
+}
+
+func TestFixture_Leaves(t *testing.T) {
+	t.Parallel()
+	finance, sales := Department("finance"), Department("sales")
+	employee := Employee()
+	f := fixify.Config{Deterministic: true}.New(t,
+		Company().With(
+			finance.With(employee),
+			sales,
+		),
+		User("alice"),
+	)
+	leaves := f.Leaves()
+	if assert.Len(t, leaves, 3) {
+		assert.Same(t, employee.Value(), leaves[0])
+		assert.Same(t, sales.Value(), leaves[1])
+		assert.IsType(t, &model.User{}, leaves[2])
+	}
+}
+
+func TestFixture_Levels(t *testing.T) {
+	t.Parallel()
+	t.Run("longest path", func(t *testing.T) {
+		t.Parallel()
+		c1, c2, c3 := Cyclic(), Cyclic(), Cyclic()
+		// c3 is placed after c2 although it is also a child of the root c1.
+		f := fixify.New(t,
+			c1.With(c2.With(c3), c3),
+		)
+		assert.Equal(t, [][]any{{c1.Value()}, {c2.Value()}, {c3.Value()}}, f.Levels())
+	})
+
+	t.Run("added models", func(t *testing.T) {
+		t.Parallel()
+		department := Department("finance")
+		f := fixify.Config{Deterministic: true}.New(t, Company().With(department))
+		f.Add(Employee().WithParent(department))
+		assert.Equal(t, []string{"company", "finance", "employee"}, levelNames(f.Levels()))
+	})
+}
+
+func TestFixture_Edges(t *testing.T) {
+	t.Parallel()
+	t.Run("tree", func(t *testing.T) {
+		t.Parallel()
+		company := Company()
+		department := Department("finance")
+		employee := Employee()
+		f := fixify.Config{Deterministic: true}.New(t,
+			company.With(department.With(employee)),
+		)
+		assert.Equal(t, []fixify.Connection{
+			{Parent: company.Value(), Child: department.Value()},
+			{Parent: department.Value(), Child: employee.Value()},
+		}, f.Edges())
+	})
+
+	t.Run("multiple labels", func(t *testing.T) {
+		t.Parallel()
+		user := User("alice")
+		follow := Follow()
+		f := fixify.New(t,
+			follow.
+				WithParentAs("followee", user).
+				WithParentAs("follower", user),
+		)
+		assert.Equal(t, []fixify.Connection{
+			{Parent: user.Value(), Child: follow.Value(), Label: "followee"},
+			{Parent: user.Value(), Child: follow.Value(), Label: "follower"},
+		}, f.Edges())
+	})
+}
+
+func levelNames(levels [][]any) []string {
+	names := make([]string, 0, len(levels))
+	for _, level := range levels {
+		names = append(names, modelNames(level))
+	}
+	return names
+}
@@ -0,0 +1,79 @@
+package fixify
+
+// Hooks are called around the visitors and the connectors in [Fixture.Apply] and [Fixture.ApplyPhases],
+// e.g. to measure the time of each visit, to log each connection, or to inject faults.
+// Nil hooks are ignored.
+type Hooks struct {
+	// BeforeVisit is called before a visitor visits the model.
+	// If it returns an error, the visitor is not called and the error is reported as an error of the visitor.
+	BeforeVisit func(model any) error
+	// AfterVisit is called after a visitor visits the model with the error returned by the visitor.
+	AfterVisit func(model any, err error)
+	// BeforeConnect is called before a connector connects the child to the parent with the label.
+	// For an ancestor connector, parent is the ancestor.
+	// For a connector taking multiple parents, e.g. [ConnectorFunc2], parent is []any of the parents and label is nil.
+	// If it returns an error, the connector is not called and the error is reported as an error of the connector.
+	BeforeConnect func(child, parent, label any) error
+	// AfterConnect is called after a connector connects the child to the parent with the label
+	// with the error returned by the connector.
+	AfterConnect func(child, parent, label any, err error)
+}
+
+// WithHooks registers hooks called in [Fixture.Apply] and [Fixture.ApplyPhases], and returns the fixture.
+// If hooks are registered multiple times, they wrap the calls like middleware:
+// the before hooks are called in the order of registration, and the after hooks in the reverse order.
+func (f *Fixture) WithHooks(hooks Hooks) *Fixture {
+	f.hooks = append(f.hooks, hooks)
+	return f
+}
+
+// connectHook calls connect, which connects the child to the parent with the label.
+type connectHook func(child, parent, label any, connect func() error) error
+
+// callVisit calls visit with the model through the hooks.
+func (f *Fixture) callVisit(model any, visit func(model any) error) error {
+	// n is the number of hooks whose BeforeVisit is called successfully.
+	n := 0
+	var err error
+	for _, h := range f.hooks {
+		if h.BeforeVisit != nil {
+			if err = h.BeforeVisit(model); err != nil {
+				break
+			}
+		}
+		n++
+	}
+	if n == len(f.hooks) {
+		err = visit(model)
+	}
+	for i := n - 1; i >= 0; i-- {
+		if h := f.hooks[i]; h.AfterVisit != nil {
+			h.AfterVisit(model, err)
+		}
+	}
+	return err
+}
+
+// callConnect calls connect through the hooks. It implements connectHook.
+func (f *Fixture) callConnect(child, parent, label any, connect func() error) error {
+	// n is the number of hooks whose BeforeConnect is called successfully.
+	n := 0
+	var err error
+	for _, h := range f.hooks {
+		if h.BeforeConnect != nil {
+			if err = h.BeforeConnect(child, parent, label); err != nil {
+				break
+			}
+		}
+		n++
+	}
+	if n == len(f.hooks) {
+		err = connect()
+	}
+	for i := n - 1; i >= 0; i-- {
+		if h := f.hooks[i]; h.AfterConnect != nil {
+			h.AfterConnect(child, parent, label, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,222 @@
+package fixify_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleFixture_WithHooks() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	f := fixify.Config{Deterministic: true}.New(t,
+		Company().With(
+			Department("finance"),
+		),
+	)
+	f.WithHooks(fixify.Hooks{
+		AfterVisit: func(model any, err error) {
+			fmt.Printf("visited %T\n", model)
+		},
+		AfterConnect: func(child, parent, label any, err error) {
+			fmt.Printf("connected %T -> %T\n", child, parent)
+		},
+	})
+	f.Apply(func(v any) error {
+		return nil
+	})
+	// Output:
+	// visited *model.Company
+	// connected *model.Department -> *model.Company
+	// visited *model.Department
+}
+
+func TestFixture_WithHooks(t *testing.T) {
+	t.Parallel()
+	setter := func(v any) error {
+		switch v := v.(type) {
+		case *model.Company:
+			v.ID = 1
+		case *model.Department:
+			v.ID = 2
+		case *model.Employee:
+			v.ID = 3
+		case *model.Student:
+			v.ID = 4
+		case *model.Classroom:
+			v.ID = 5
+		case *model.Enrollment:
+			v.ID = 6
+		case *model.User:
+			v.ID = 7
+		case *model.Follow:
+			v.ID = 8
+		}
+		return nil
+	}
+
+	t.Run("order of hooks", func(t *testing.T) {
+		t.Parallel()
+		var events []string
+		hooks := func(name string) fixify.Hooks {
+			return fixify.Hooks{
+				BeforeVisit: func(model any) error {
+					events = append(events, fmt.Sprintf("%s: before visit %T", name, model))
+					return nil
+				},
+				AfterVisit: func(model any, err error) {
+					events = append(events, fmt.Sprintf("%s: after visit %T", name, model))
+				},
+				BeforeConnect: func(child, parent, label any) error {
+					events = append(events, fmt.Sprintf("%s: before connect %T -> %T", name, child, parent))
+					return nil
+				},
+				AfterConnect: func(child, parent, label any, err error) {
+					events = append(events, fmt.Sprintf("%s: after connect %T -> %T", name, child, parent))
+				},
+			}
+		}
+		department := Department("finance")
+		f := fixify.Config{Deterministic: true}.New(t, Company().With(department))
+		f.WithHooks(hooks("outer")).WithHooks(hooks("inner"))
+		f.Apply(func(v any) error {
+			events = append(events, fmt.Sprintf("visit %T", v))
+			return setter(v)
+		})
+		assert.Equal(t, []string{
+			"outer: before visit *model.Company",
+			"inner: before visit *model.Company",
+			"visit *model.Company",
+			"inner: after visit *model.Company",
+			"outer: after visit *model.Company",
+			"outer: before connect *model.Department -> *model.Company",
+			"inner: before connect *model.Department -> *model.Company",
+			"inner: after connect *model.Department -> *model.Company",
+			"outer: after connect *model.Department -> *model.Company",
+			"outer: before visit *model.Department",
+			"inner: before visit *model.Department",
+			"visit *model.Department",
+			"inner: after visit *model.Department",
+			"outer: after visit *model.Department",
+		}, events)
+		assert.Equal(t, int64(1), department.Value().CompanyID)
+	})
+
+	t.Run("label", func(t *testing.T) {
+		t.Parallel()
+		var labels []any
+		f := fixify.Config{Deterministic: true}.New(t,
+			Follow().
+				WithParentAs("follower", User("alice")).
+				WithParentAs("followee", User("bob")),
+		)
+		f.WithHooks(fixify.Hooks{
+			BeforeConnect: func(child, parent, label any) error {
+				labels = append(labels, label)
+				return nil
+			},
+		})
+		f.Apply(setter)
+		assert.Equal(t, []any{"follower", "followee"}, labels)
+	})
+
+	t.Run("fault in visit", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		var visited []any
+		var afterErr error
+		f := fixify.New(dt, Company())
+		f.WithHooks(fixify.Hooks{
+			BeforeVisit: func(model any) error {
+				return errors.New("injected")
+			},
+			AfterVisit: func(model any, err error) {
+				afterErr = err
+			},
+		})
+		f.Apply(func(v any) error {
+			visited = append(visited, v)
+			return nil
+		})
+		assert.Empty(t, visited)
+		assert.Nil(t, afterErr, "AfterVisit of the hook whose BeforeVisit fails is not called")
+		assert.Equal(t, []string{"failed to visit &{0}: injected"}, dt.messages)
+	})
+
+	t.Run("fault in inner hook", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		var afterErr error
+		department := Department("finance")
+		f := fixify.New(dt, Company().With(department))
+		f.WithHooks(fixify.Hooks{
+			AfterConnect: func(child, parent, label any, err error) {
+				afterErr = err
+			},
+		}).WithHooks(fixify.Hooks{
+			BeforeConnect: func(child, parent, label any) error {
+				return errors.New("injected")
+			},
+		})
+		f.Apply(setter)
+		assert.EqualError(t, afterErr, "injected")
+		assert.Equal(t, int64(0), department.Value().CompanyID)
+		assert.Equal(t, []string{"failed to connect: child *model.Department -> parent *model.Company: injected"}, dt.messages)
+	})
+
+	t.Run("backward connector", func(t *testing.T) {
+		t.Parallel()
+		var connected []string
+		f := fixify.New(t, Department("finance").With(Manager()))
+		f.WithHooks(fixify.Hooks{
+			AfterConnect: func(child, parent, label any, err error) {
+				connected = append(connected, fmt.Sprintf("%T -> %T", child, parent))
+			},
+		})
+		f.Apply(setter)
+		// the forward connector and the backward connector.
+		assert.Equal(t, []string{
+			"*model.Employee -> *model.Department",
+			"*model.Employee -> *model.Department",
+		}, connected)
+	})
+
+	t.Run("ancestor connector", func(t *testing.T) {
+		t.Parallel()
+		var parents []any
+		company := Company()
+		f := fixify.New(t, company.With(Department("finance").With(CompanyEmployee())))
+		f.WithHooks(fixify.Hooks{
+			BeforeConnect: func(child, parent, label any) error {
+				if _, ok := child.(*model.Employee); ok {
+					parents = append(parents, parent)
+				}
+				return nil
+			},
+		})
+		f.Apply(setter)
+		if assert.Len(t, parents, 2) {
+			assert.IsType(t, &model.Department{}, parents[0])
+			assert.Same(t, company.Value(), parents[1])
+		}
+	})
+
+	t.Run("multi-parent connector", func(t *testing.T) {
+		t.Parallel()
+		var parents []any
+		student, classroom := Student(), Classroom()
+		f := fixify.New(t, CompositeEnrollment().WithParent(student).WithParent(classroom))
+		f.WithHooks(fixify.Hooks{
+			BeforeConnect: func(child, parent, label any) error {
+				parents = append(parents, parent)
+				return nil
+			},
+		})
+		f.Apply(setter)
+		assert.Equal(t, []any{[]any{student.Value(), classroom.Value()}}, parents)
+	})
+}
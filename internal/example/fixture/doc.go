@@ -0,0 +1,4 @@
+// Package fixture contains the fixture constructors generated by fixifygen from the package model.
+package fixture
+
+//go:generate go run ../../../cmd/fixifygen -dir ../model -o fixture_gen.go -ref Follow.FollowerID=User -ref Follow.FolloweeID=User -ref Ticket.AssigneeID=User -ref Ticket.ReviewerID=User
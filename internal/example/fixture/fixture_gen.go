@@ -0,0 +1,129 @@
+// Code generated by fixifygen; DO NOT EDIT.
+
+package fixture
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+)
+
+// Library returns a fixture of [model.Library].
+func Library() *fixify.Model[model.Library] {
+	return fixify.NewModel(new(model.Library))
+}
+
+// Book returns a fixture of [model.Book].
+func Book() *fixify.Model[model.Book] {
+	return fixify.NewModel(new(model.Book),
+		fixify.ConnectorFunc(func(_ testing.TB, book *model.Book, library *model.Library) {
+			book.LibraryID = library.ID
+		}),
+		fixify.ConnectorFunc(func(_ testing.TB, book *model.Book, author *model.Author) {
+			book.AuthorID = author.ID
+		}),
+	)
+}
+
+// Page returns a fixture of [model.Page].
+func Page() *fixify.Model[model.Page] {
+	return fixify.NewModel(new(model.Page),
+		fixify.ConnectorFunc(func(_ testing.TB, page *model.Page, book *model.Book) {
+			page.BookID = book.ID
+		}),
+	)
+}
+
+// Author returns a fixture of [model.Author].
+func Author() *fixify.Model[model.Author] {
+	return fixify.NewModel(new(model.Author))
+}
+
+// Company returns a fixture of [model.Company].
+func Company() *fixify.Model[model.Company] {
+	return fixify.NewModel(new(model.Company))
+}
+
+// Department returns a fixture of [model.Department].
+func Department() *fixify.Model[model.Department] {
+	return fixify.NewModel(new(model.Department),
+		fixify.ConnectorFunc(func(_ testing.TB, department *model.Department, company *model.Company) {
+			department.CompanyID = company.ID
+		}),
+	)
+}
+
+// Employee returns a fixture of [model.Employee].
+func Employee() *fixify.Model[model.Employee] {
+	return fixify.NewModel(new(model.Employee),
+		fixify.ConnectorFunc(func(_ testing.TB, employee *model.Employee, department *model.Department) {
+			employee.DepartmentID = department.ID
+		}),
+		fixify.ConnectorFunc(func(_ testing.TB, employee *model.Employee, company *model.Company) {
+			employee.CompanyID = company.ID
+		}),
+	)
+}
+
+// Classroom returns a fixture of [model.Classroom].
+func Classroom() *fixify.Model[model.Classroom] {
+	return fixify.NewModel(new(model.Classroom))
+}
+
+// Student returns a fixture of [model.Student].
+func Student() *fixify.Model[model.Student] {
+	return fixify.NewModel(new(model.Student))
+}
+
+// Enrollment returns a fixture of [model.Enrollment].
+func Enrollment() *fixify.Model[model.Enrollment] {
+	return fixify.NewModel(new(model.Enrollment),
+		fixify.ConnectorFunc(func(_ testing.TB, enrollment *model.Enrollment, student *model.Student) {
+			enrollment.StudentID = student.ID
+		}),
+		fixify.ConnectorFunc(func(_ testing.TB, enrollment *model.Enrollment, classroom *model.Classroom) {
+			enrollment.ClassroomID = classroom.ID
+		}),
+	)
+}
+
+// User returns a fixture of [model.User].
+func User() *fixify.Model[model.User] {
+	return fixify.NewModel(new(model.User))
+}
+
+// Follow returns a fixture of [model.Follow].
+func Follow() *fixify.Model[model.Follow] {
+	return fixify.NewModel(new(model.Follow),
+		fixify.ConnectorFuncWithLabel("follower", func(_ testing.TB, follow *model.Follow, follower *model.User) {
+			follow.FollowerID = follower.ID
+		}),
+		fixify.ConnectorFuncWithLabel("followee", func(_ testing.TB, follow *model.Follow, followee *model.User) {
+			follow.FolloweeID = followee.ID
+		}),
+	)
+}
+
+// Ticket returns a fixture of [model.Ticket].
+func Ticket() *fixify.Model[model.Ticket] {
+	return fixify.NewModel(new(model.Ticket),
+		fixify.Optional(fixify.ConnectorFuncWithLabel("assignee", func(_ testing.TB, ticket *model.Ticket, assignee *model.User) {
+			id := assignee.ID
+			ticket.AssigneeID = &id
+		})),
+		fixify.Optional(fixify.ConnectorFuncWithLabel("reviewer", func(_ testing.TB, ticket *model.Ticket, reviewer *model.User) {
+			ticket.ReviewerID = sql.NullInt64{Int64: reviewer.ID, Valid: true}
+		})),
+	)
+}
+
+// Cyclic returns a fixture of [model.Cyclic].
+func Cyclic() *fixify.Model[model.Cyclic] {
+	return fixify.NewModel(new(model.Cyclic),
+		fixify.ConnectorFunc(func(_ testing.TB, cyclic *model.Cyclic, parent *model.Cyclic) {
+			cyclic.CyclicID = parent.ID
+		}),
+	)
+}
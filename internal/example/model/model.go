@@ -1,5 +1,7 @@
 package model
 
+import "database/sql"
+
 type Library struct {
 	ID   int64
 	Name string
@@ -31,11 +33,13 @@ type Department struct {
 	ID        int64
 	CompanyID int64
 	Name      string
+	ManagerID int64
 }
 
 type Employee struct {
 	ID           int64
 	DepartmentID int64
+	CompanyID    int64
 }
 
 type Classroom struct {
@@ -63,6 +67,12 @@ type Follow struct {
 	FolloweeID int64
 }
 
+type Ticket struct {
+	ID         int64
+	AssigneeID *int64
+	ReviewerID sql.NullInt64
+}
+
 // unsupported model.
 type Cyclic struct {
 	ID       int64
@@ -0,0 +1,4 @@
+// Package fixture contains the fixture constructors generated by fixifygen from schema.sql.
+package fixture
+
+//go:generate go run ../../../../cmd/fixifygen -schema ../schema.sql -dir ../model -o fixture_gen.go
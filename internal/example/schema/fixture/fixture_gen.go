@@ -0,0 +1,57 @@
+// Code generated by fixifygen; DO NOT EDIT.
+
+package fixture
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/schema/model"
+)
+
+// User returns a fixture of [model.User].
+func User() *fixify.Model[model.User] {
+	return fixify.NewModel(new(model.User))
+}
+
+// Company returns a fixture of [model.Company].
+func Company() *fixify.Model[model.Company] {
+	return fixify.NewModel(new(model.Company))
+}
+
+// Department returns a fixture of [model.Department].
+func Department() *fixify.Model[model.Department] {
+	return fixify.NewModel(new(model.Department),
+		fixify.ConnectorFunc(func(_ testing.TB, department *model.Department, company *model.Company) {
+			department.CompanyID = company.ID
+		}),
+	)
+}
+
+// Employee returns a fixture of [model.Employee].
+func Employee() *fixify.Model[model.Employee] {
+	return fixify.NewModel(new(model.Employee),
+		fixify.ConnectorFunc(func(_ testing.TB, employee *model.Employee, department *model.Department) {
+			employee.DepartmentID = department.ID
+		}),
+		fixify.ConnectorFunc(func(_ testing.TB, employee *model.Employee, user *model.User) {
+			employee.UserID = user.ID
+		}),
+		fixify.Optional(fixify.ConnectorFunc(func(_ testing.TB, employee *model.Employee, parent *model.Employee) {
+			employee.MentorID = sql.NullInt64{Int64: parent.ID, Valid: true}
+		})),
+	)
+}
+
+// Follow returns a fixture of [model.Follow].
+func Follow() *fixify.Model[model.Follow] {
+	return fixify.NewModel(new(model.Follow),
+		fixify.ConnectorFuncWithLabel("follower_id", func(_ testing.TB, follow *model.Follow, follower *model.User) {
+			follow.FollowerID = follower.ID
+		}),
+		fixify.ConnectorFuncWithLabel("followee_id", func(_ testing.TB, follow *model.Follow, followee *model.User) {
+			follow.FolloweeID = followee.ID
+		}),
+	)
+}
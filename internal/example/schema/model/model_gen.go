@@ -0,0 +1,37 @@
+// Code generated by fixifygen; DO NOT EDIT.
+
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Company is a row of the table companies.
+type Company struct {
+	ID   int64
+	Name string
+}
+
+// Department is a row of the table departments.
+type Department struct {
+	ID        int64
+	CompanyID int64
+	Name      string
+	CreatedAt time.Time
+}
+
+// Employee is a row of the table employees.
+type Employee struct {
+	ID           int64
+	DepartmentID int64
+	UserID       int64
+	MentorID     sql.NullInt64
+}
+
+// Follow is a row of the table follows.
+type Follow struct {
+	ID         int64
+	FollowerID int64
+	FolloweeID int64
+}
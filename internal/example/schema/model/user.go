@@ -0,0 +1,9 @@
+// Package model contains the models for the tables in schema.sql.
+// The structs except for User are generated by fixifygen.
+package model
+
+// User is declared by hand, so that fixifygen does not generate it.
+type User struct {
+	ID   int64
+	Name string
+}
@@ -0,0 +1,42 @@
+-- schema of the example models generated by fixifygen -schema.
+
+CREATE TABLE users (
+  id BIGINT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE companies (
+  id BIGINT NOT NULL,
+  name TEXT NOT NULL,
+  PRIMARY KEY (id)
+);
+
+CREATE TABLE departments (
+  id BIGINT PRIMARY KEY,
+  company_id BIGINT NOT NULL REFERENCES companies (id),
+  name TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE employees (
+  id BIGINT PRIMARY KEY,
+  department_id BIGINT NOT NULL,
+  user_id BIGINT NOT NULL,
+  mentor_id BIGINT,
+  CONSTRAINT fk_employees_department FOREIGN KEY (department_id) REFERENCES departments (id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users
+);
+
+CREATE TABLE follows (
+  id BIGINT PRIMARY KEY,
+  follower_id BIGINT NOT NULL,
+  followee_id BIGINT NOT NULL,
+  FOREIGN KEY (follower_id) REFERENCES users (id),
+  FOREIGN KEY (followee_id) REFERENCES users (id),
+  UNIQUE (follower_id, followee_id)
+);
+
+CREATE INDEX idx_follows_followee ON follows (followee_id);
+
+/* mentors are employees of any company. */
+ALTER TABLE employees ADD CONSTRAINT fk_employees_mentor FOREIGN KEY (mentor_id) REFERENCES employees (id);
@@ -0,0 +1,462 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Table is a table declared by CREATE TABLE.
+type Table struct {
+	Name       string
+	Columns    []Column
+	PrimaryKey []string
+	References []Reference
+}
+
+// Column is a column of a table.
+type Column struct {
+	Name string
+	// Type is the type name in upper case without its parameters, e.g. VARCHAR for VARCHAR(255).
+	Type    string
+	NotNull bool
+}
+
+// Reference is a foreign key of a table.
+type Reference struct {
+	Columns []string
+	Table   string
+	// RefColumns is the columns of Table referred to.
+	// It is empty if the foreign key refers to the primary key.
+	RefColumns []string
+}
+
+// ParseDDL parses CREATE TABLE statements and returns the tables appended to tables in the declaration order.
+// Foreign keys added by ALTER TABLE ... ADD FOREIGN KEY are also collected,
+// which may refer to the tables declared in the previous files given by tables.
+// The other statements are ignored.
+func ParseDDL(src string, tables []Table) ([]Table, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &ddlParser{tokens: tokens}
+	for !p.done() {
+		switch {
+		case p.accept("CREATE"):
+			p.accept("TEMPORARY")
+			p.accept("TEMP")
+			if !p.accept("TABLE") {
+				p.skipStatement()
+				continue
+			}
+			t, err := p.createTable()
+			if err != nil {
+				return nil, err
+			}
+			tables = append(tables, t)
+		case p.accept("ALTER"):
+			if !p.accept("TABLE") {
+				p.skipStatement()
+				continue
+			}
+			if err := p.alterTable(tables); err != nil {
+				return nil, err
+			}
+		default:
+			p.skipStatement()
+		}
+	}
+	return tables, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenQuoted
+	tokenSymbol
+	tokenString
+)
+
+type ddlToken struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+// tokenize splits src into words, quoted identifiers, symbols and string literals, dropping comments.
+func tokenize(src string) ([]ddlToken, error) {
+	var tokens []ddlToken
+	line := 1
+	r := []rune(src)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case unicode.IsSpace(c):
+			i++
+		case c == '-' && i+1 < len(r) && r[i+1] == '-':
+			for i < len(r) && r[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(r) && r[i+1] == '*':
+			j := i + 2
+			for j+1 < len(r) && (r[j] != '*' || r[j+1] != '/') {
+				j++
+			}
+			if j+1 >= len(r) {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(string(r[i:j]), "\n")
+			i = j + 2
+		case c == '"' || c == '`' || c == '[' || c == '\'':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			j := i + 1
+			for j < len(r) && r[j] != closing {
+				j++
+			}
+			if j == len(r) {
+				return nil, fmt.Errorf("line %d: unterminated %c", line, c)
+			}
+			kind := tokenQuoted
+			if c == '\'' {
+				kind = tokenString
+			}
+			tokens = append(tokens, ddlToken{kind: kind, text: string(r[i+1 : j]), line: line})
+			line += strings.Count(string(r[i+1:j]), "\n")
+			i = j + 1
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_':
+			j := i
+			for j < len(r) && (unicode.IsLetter(r[j]) || unicode.IsDigit(r[j]) || r[j] == '_' || r[j] == '$') {
+				j++
+			}
+			tokens = append(tokens, ddlToken{kind: tokenWord, text: string(r[i:j]), line: line})
+			i = j
+		default:
+			tokens = append(tokens, ddlToken{kind: tokenSymbol, text: string(c), line: line})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+type ddlParser struct {
+	tokens []ddlToken
+	pos    int
+}
+
+func (p *ddlParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *ddlParser) peek() ddlToken {
+	if p.done() {
+		return ddlToken{kind: tokenSymbol}
+	}
+	return p.tokens[p.pos]
+}
+
+// accept consumes the next token if it is the keyword or the symbol.
+func (p *ddlParser) accept(s string) bool {
+	t := p.peek()
+	if (t.kind == tokenWord || t.kind == tokenSymbol) && strings.EqualFold(t.text, s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *ddlParser) errorf(format string, args ...any) error {
+	line := 0
+	if p.done() {
+		if len(p.tokens) > 0 {
+			line = p.tokens[len(p.tokens)-1].line
+		}
+	} else {
+		line = p.peek().line
+	}
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *ddlParser) expect(s string) error {
+	if !p.accept(s) {
+		return p.errorf("expected %s but got %q", s, p.peek().text)
+	}
+	return nil
+}
+
+// skipStatement skips tokens until the end of the statement.
+func (p *ddlParser) skipStatement() {
+	for !p.done() && !p.accept(";") {
+		p.pos++
+	}
+}
+
+// skipParens skips tokens until the closing parenthesis matching the last opening one.
+func (p *ddlParser) skipParens() {
+	for depth := 1; !p.done() && depth > 0; p.pos++ {
+		switch t := p.peek(); {
+		case t.kind == tokenSymbol && t.text == "(":
+			depth++
+		case t.kind == tokenSymbol && t.text == ")":
+			depth--
+		}
+	}
+}
+
+// ident consumes an identifier, which may be qualified by a schema.
+// It returns the last part of the qualified name.
+func (p *ddlParser) ident() (string, error) {
+	var name string
+	for {
+		t := p.peek()
+		if t.kind != tokenWord && t.kind != tokenQuoted {
+			return "", p.errorf("expected identifier but got %q", t.text)
+		}
+		p.pos++
+		name = t.text
+		if !p.accept(".") {
+			return name, nil
+		}
+	}
+}
+
+// identList consumes a parenthesized list of identifiers.
+func (p *ddlParser) identList() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		// skip the length and the order, e.g. name(10) DESC.
+		if p.accept("(") {
+			p.skipParens()
+		}
+		p.accept("ASC")
+		p.accept("DESC")
+		if p.accept(")") {
+			return names, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *ddlParser) createTable() (Table, error) {
+	if p.accept("IF") {
+		if err := p.expect("NOT"); err != nil {
+			return Table{}, err
+		}
+		if err := p.expect("EXISTS"); err != nil {
+			return Table{}, err
+		}
+	}
+	name, err := p.ident()
+	if err != nil {
+		return Table{}, err
+	}
+	t := Table{Name: name}
+	if err := p.expect("("); err != nil {
+		return Table{}, err
+	}
+	for {
+		if err := p.tableElement(&t); err != nil {
+			return Table{}, err
+		}
+		if p.accept(")") {
+			break
+		}
+		if err := p.expect(","); err != nil {
+			return Table{}, err
+		}
+	}
+	// table options.
+	p.skipStatement()
+	for i, c := range t.Columns {
+		for _, pk := range t.PrimaryKey {
+			if c.Name == pk {
+				t.Columns[i].NotNull = true
+			}
+		}
+	}
+	return t, nil
+}
+
+func (p *ddlParser) tableElement(t *Table) error {
+	if p.accept("CONSTRAINT") {
+		if _, err := p.ident(); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.accept("PRIMARY"):
+		if err := p.expect("KEY"); err != nil {
+			return err
+		}
+		cols, err := p.identList()
+		if err != nil {
+			return err
+		}
+		t.PrimaryKey = cols
+		p.skipElement()
+		return nil
+	case p.accept("FOREIGN"):
+		ref, err := p.foreignKey()
+		if err != nil {
+			return err
+		}
+		t.References = append(t.References, ref)
+		return nil
+	case p.accept("UNIQUE"), p.accept("CHECK"), p.accept("INDEX"), p.accept("KEY"), p.accept("FULLTEXT"), p.accept("EXCLUDE"):
+		p.skipElement()
+		return nil
+	}
+	return p.column(t)
+}
+
+// skipElement skips tokens until the end of the table element or the alteration.
+func (p *ddlParser) skipElement() {
+	for !p.done() {
+		t := p.peek()
+		if t.kind == tokenSymbol && (t.text == "," || t.text == ")" || t.text == ";") {
+			return
+		}
+		p.pos++
+		if t.kind == tokenSymbol && t.text == "(" {
+			p.skipParens()
+		}
+	}
+}
+
+// foreignKey parses the rest of FOREIGN KEY (columns) REFERENCES table (columns).
+func (p *ddlParser) foreignKey() (Reference, error) {
+	if err := p.expect("KEY"); err != nil {
+		return Reference{}, err
+	}
+	cols, err := p.identList()
+	if err != nil {
+		return Reference{}, err
+	}
+	if err := p.expect("REFERENCES"); err != nil {
+		return Reference{}, err
+	}
+	ref, err := p.references(cols)
+	if err != nil {
+		return Reference{}, err
+	}
+	p.skipElement()
+	return ref, nil
+}
+
+// references parses the rest of REFERENCES table [(columns)].
+func (p *ddlParser) references(cols []string) (Reference, error) {
+	table, err := p.ident()
+	if err != nil {
+		return Reference{}, err
+	}
+	ref := Reference{Columns: cols, Table: table}
+	if t := p.peek(); t.kind == tokenSymbol && t.text == "(" {
+		if ref.RefColumns, err = p.identList(); err != nil {
+			return Reference{}, err
+		}
+	}
+	return ref, nil
+}
+
+func (p *ddlParser) column(t *Table) error {
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	typ := p.peek()
+	if typ.kind != tokenWord {
+		return p.errorf("expected type of column %s but got %q", name, typ.text)
+	}
+	p.pos++
+	c := Column{Name: name, Type: strings.ToUpper(typ.text)}
+	for !p.done() {
+		tok := p.peek()
+		if tok.kind == tokenSymbol && (tok.text == "," || tok.text == ")" || tok.text == ";") {
+			break
+		}
+		switch {
+		case p.accept("NOT"):
+			if p.accept("NULL") {
+				c.NotNull = true
+			}
+		case p.accept("PRIMARY"):
+			p.accept("KEY")
+			t.PrimaryKey = []string{name}
+		case p.accept("REFERENCES"):
+			ref, err := p.references([]string{name})
+			if err != nil {
+				return err
+			}
+			t.References = append(t.References, ref)
+		case p.accept("("):
+			p.skipParens()
+		default:
+			p.pos++
+		}
+	}
+	t.Columns = append(t.Columns, c)
+	return nil
+}
+
+// alterTable parses ALTER TABLE name ADD [CONSTRAINT name] FOREIGN KEY ... and adds the foreign keys to the table.
+// The other alterations are ignored.
+func (p *ddlParser) alterTable(tables []Table) error {
+	p.accept("ONLY")
+	if p.accept("IF") {
+		if err := p.expect("EXISTS"); err != nil {
+			return err
+		}
+	}
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	var table *Table
+	for i := range tables {
+		if tables[i].Name == name {
+			table = &tables[i]
+		}
+	}
+	for {
+		if p.accept("ADD") {
+			if p.accept("CONSTRAINT") {
+				if _, err := p.ident(); err != nil {
+					return err
+				}
+			}
+			if p.accept("FOREIGN") {
+				if table == nil {
+					return p.errorf("unknown table %s", name)
+				}
+				ref, err := p.foreignKey()
+				if err != nil {
+					return err
+				}
+				table.References = append(table.References, ref)
+			}
+		}
+		p.skipElement()
+		if !p.accept(",") {
+			break
+		}
+	}
+	p.skipStatement()
+	return nil
+}
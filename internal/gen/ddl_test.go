@@ -0,0 +1,101 @@
+package gen_test
+
+import (
+	"testing"
+
+	"github.com/qawatake/fixify/internal/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDDL(t *testing.T) {
+	t.Parallel()
+	t.Run("tables", func(t *testing.T) {
+		t.Parallel()
+		tables, err := gen.ParseDDL(`
+-- comment (with parenthesis
+CREATE TABLE IF NOT EXISTS public."users" (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  name VARCHAR(255) NOT NULL DEFAULT 'a, b)',
+  PRIMARY KEY (id),
+  KEY idx_name (name(10) DESC)
+) ENGINE=InnoDB;
+
+/* multi-line
+   comment; */
+CREATE TABLE follows (
+  id INTEGER PRIMARY KEY,
+  follower_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  followee_id BIGINT,
+  CONSTRAINT fk_followee FOREIGN KEY (followee_id) REFERENCES users,
+  CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX idx ON follows (followee_id);
+INSERT INTO users (id, name) VALUES (1, 'CREATE TABLE');
+`, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []gen.Table{
+			{
+				Name: "users",
+				Columns: []gen.Column{
+					{Name: "id", Type: "BIGINT", NotNull: true},
+					{Name: "name", Type: "VARCHAR", NotNull: true},
+				},
+				PrimaryKey: []string{"id"},
+			},
+			{
+				Name: "follows",
+				Columns: []gen.Column{
+					{Name: "id", Type: "INTEGER", NotNull: true},
+					{Name: "follower_id", Type: "BIGINT", NotNull: true},
+					{Name: "followee_id", Type: "BIGINT"},
+				},
+				PrimaryKey: []string{"id"},
+				References: []gen.Reference{
+					{Columns: []string{"follower_id"}, Table: "users", RefColumns: []string{"id"}},
+					{Columns: []string{"followee_id"}, Table: "users"},
+				},
+			},
+		}, tables)
+	})
+
+	t.Run("alter table", func(t *testing.T) {
+		t.Parallel()
+		tables, err := gen.ParseDDL(`CREATE TABLE users (id BIGINT PRIMARY KEY, inviter_id BIGINT);`, nil)
+		require.NoError(t, err)
+		// foreign keys may be added in another file.
+		tables, err = gen.ParseDDL(`
+ALTER TABLE ONLY users
+  ADD COLUMN age INT,
+  ADD CONSTRAINT fk_inviter FOREIGN KEY (inviter_id) REFERENCES users (id);
+ALTER TABLE users DROP COLUMN age;
+`, tables)
+		require.NoError(t, err)
+		require.Len(t, tables, 1)
+		assert.Equal(t, []gen.Reference{
+			{Columns: []string{"inviter_id"}, Table: "users", RefColumns: []string{"id"}},
+		}, tables[0].References)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		for name, src := range map[string]string{
+			"unterminated comment":    "/* comment",
+			"unterminated identifier": `CREATE TABLE "users (id BIGINT);`,
+			"missing parenthesis":     "CREATE TABLE users id BIGINT;",
+			"missing type":            "CREATE TABLE users (id);",
+			"invalid foreign key":     "CREATE TABLE users (id BIGINT, FOREIGN KEY id REFERENCES users (id));",
+			"unknown table":           "ALTER TABLE users ADD FOREIGN KEY (id) REFERENCES users (id);",
+		} {
+			_, err := gen.ParseDDL(src, nil)
+			assert.Error(t, err, name)
+		}
+	})
+
+	t.Run("error line", func(t *testing.T) {
+		t.Parallel()
+		_, err := gen.ParseDDL("CREATE TABLE users (\n  id BIGINT,\n  FOREIGN KEY id\n);", nil)
+		assert.ErrorContains(t, err, "line 3:")
+	})
+}
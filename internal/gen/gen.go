@@ -0,0 +1,137 @@
+// Package gen generates fixture constructors of fixify from model structs.
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// File is a Go source file containing the fixture constructors of models.
+type File struct {
+	// Package is the package name of the file.
+	Package string
+	// ModelImport is the import path of the package declaring the models.
+	ModelImport string
+	// ModelPackage is the package name of the package declaring the models.
+	ModelPackage string
+	Models       []Model
+}
+
+// Generate returns the formatted source code of the file.
+// For each model, it generates a constructor with the same name as the model,
+// which returns *fixify.Model with a connector for each foreign key.
+func Generate(f File) ([]byte, error) {
+	if f.Package == "" || f.ModelImport == "" || f.ModelPackage == "" {
+		return nil, errors.New("package, model import path and model package name are required")
+	}
+	var usesTesting, usesSQL bool
+	for _, m := range f.Models {
+		for _, fk := range m.ForeignKeys {
+			usesTesting = true
+			if _, ok := nullValue(fk.Type); ok {
+				usesSQL = true
+			}
+		}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by fixifygen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", f.Package)
+	b.WriteString("import (\n")
+	if usesSQL {
+		b.WriteString("\"database/sql\"\n")
+	}
+	if usesTesting {
+		b.WriteString("\"testing\"\n")
+	}
+	b.WriteString("\n\"github.com/qawatake/fixify\"\n")
+	if f.ModelPackage == f.ModelImport[strings.LastIndex(f.ModelImport, "/")+1:] {
+		fmt.Fprintf(&b, "%q\n", f.ModelImport)
+	} else {
+		fmt.Fprintf(&b, "%s %q\n", f.ModelPackage, f.ModelImport)
+	}
+	b.WriteString(")\n")
+	reserved := map[string]bool{"fixify": true, "testing": true, "sql": true, f.ModelPackage: true}
+	for _, m := range f.Models {
+		writeModel(&b, f.ModelPackage, m, reserved)
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated code: %w", err)
+	}
+	return src, nil
+}
+
+func writeModel(b *bytes.Buffer, pkg string, m Model, reserved map[string]bool) {
+	typ := pkg + "." + m.Name
+	fmt.Fprintf(b, "\n// %s returns a fixture of [%s].\n", m.Name, typ)
+	fmt.Fprintf(b, "func %s() *fixify.Model[%s] {\n", m.Name, typ)
+	if len(m.ForeignKeys) == 0 {
+		fmt.Fprintf(b, "return fixify.NewModel(new(%s))\n}\n", typ)
+		return
+	}
+	fmt.Fprintf(b, "return fixify.NewModel(new(%s),\n", typ)
+	child := varName(m.Name, "child", reserved)
+	for _, fk := range m.ForeignKeys {
+		parentTyp := pkg + "." + fk.Parent
+		name := fk.Label
+		if name == "" {
+			name = fk.Parent
+		} else if strings.Contains(name, "_") {
+			// labels named after columns, e.g. follower_id -> follower.
+			name = fieldName(strings.TrimSuffix(name, "_id"))
+		}
+		parent := varName(name, "parent", reserved)
+		if parent == child {
+			parent = "parent"
+		}
+		if fk.Optional {
+			b.WriteString("fixify.Optional(")
+		}
+		if fk.Label == "" {
+			fmt.Fprintf(b, "fixify.ConnectorFunc(")
+		} else {
+			fmt.Fprintf(b, "fixify.ConnectorFuncWithLabel(%q, ", fk.Label)
+		}
+		fmt.Fprintf(b, "func(_ testing.TB, %s *%s, %s *%s) {\n", child, typ, parent, parentTyp)
+		value := parent + "." + fk.ParentField
+		switch v, ok := nullValue(fk.Type); {
+		case ok:
+			fmt.Fprintf(b, "%s.%s = %s{%s: %s, Valid: true}\n", child, fk.Field, fk.Type, v.name, value)
+		case strings.HasPrefix(fk.Type, "*"):
+			// copy the value not to share it with the parent.
+			fmt.Fprintf(b, "%s := %s\n%s.%s = &%[1]s\n", idName(child, parent), value, child, fk.Field)
+		default:
+			fmt.Fprintf(b, "%s.%s = %s\n", child, fk.Field, value)
+		}
+		b.WriteString("})")
+		if fk.Optional {
+			b.WriteString(")")
+		}
+		b.WriteString(",\n")
+	}
+	b.WriteString(")\n}\n")
+}
+
+// varName returns the name of a variable holding a value of the type.
+// It returns fallback if the name conflicts with keywords, predeclared identifiers or reserved names.
+func varName(typ, fallback string, reserved map[string]bool) string {
+	name := lowerFirst(typ)
+	if token.IsKeyword(name) || types.Universe.Lookup(name) != nil || reserved[name] {
+		return fallback
+	}
+	return name
+}
+
+// idName returns the name of a variable holding a copy of the ID, which conflicts with neither child nor parent.
+func idName(child, parent string) string {
+	name := "id"
+	for name == child || name == parent {
+		name += "_"
+	}
+	return name
+}
@@ -0,0 +1,153 @@
+package gen_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/fixture"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/qawatake/fixify/internal/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	t.Run("up to date", func(t *testing.T) {
+		t.Parallel()
+		pkg, structs, err := gen.ParseDir("../example/model")
+		require.NoError(t, err)
+		models, err := gen.Infer(structs, map[string]string{
+			"Follow.FollowerID": "User",
+			"Follow.FolloweeID": "User",
+			"Ticket.AssigneeID": "User",
+			"Ticket.ReviewerID": "User",
+		})
+		require.NoError(t, err)
+		got, err := gen.Generate(gen.File{
+			Package:      "fixture",
+			ModelImport:  "github.com/qawatake/fixify/internal/example/model",
+			ModelPackage: pkg,
+			Models:       models,
+		})
+		require.NoError(t, err)
+		want, err := os.ReadFile("../example/fixture/fixture_gen.go")
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "run go generate ./internal/example/fixture")
+	})
+
+	t.Run("conflicting names", func(t *testing.T) {
+		t.Parallel()
+		got, err := gen.Generate(gen.File{
+			Package:      "fixture",
+			ModelImport:  "example.com/model",
+			ModelPackage: "model",
+			Models: []gen.Model{
+				{Name: "Model"},
+				{Name: "Type", ForeignKeys: []gen.ForeignKey{
+					{Field: "ModelID", Type: "*int64", Parent: "Model", ParentField: "ID", Label: "id"},
+				}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, string(got), "func(_ testing.TB, child *model.Type, id *model.Model) {")
+		assert.Contains(t, string(got), "id_ := id.ID\n\t\t\tchild.ModelID = &id_")
+	})
+
+	t.Run("missing import path", func(t *testing.T) {
+		t.Parallel()
+		_, err := gen.Generate(gen.File{Package: "fixture", ModelPackage: "model"})
+		assert.Error(t, err)
+	})
+}
+
+func TestInfer(t *testing.T) {
+	t.Parallel()
+	structs := []gen.Struct{
+		{Name: "User", Fields: []gen.Field{{Name: "ID", Type: "int64"}}},
+		{Name: "Group", Fields: []gen.Field{{Name: "ID", Type: "string"}}},
+		{Name: "Member", Fields: []gen.Field{
+			{Name: "ID", Type: "int64"},
+			{Name: "UserID", Type: "int64"},
+			{Name: "GroupID", Type: "int64"}, // incompatible with Group.ID
+			{Name: "InviterID", Type: "sql.NullInt64"},
+			{Name: "OwnerID", Type: "int64"}, // no struct Owner
+		}},
+	}
+
+	t.Run("convention", func(t *testing.T) {
+		t.Parallel()
+		models, err := gen.Infer(structs, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []gen.Model{
+			{Name: "User"},
+			{Name: "Group"},
+			{Name: "Member", ForeignKeys: []gen.ForeignKey{
+				{Field: "UserID", Type: "int64", Parent: "User", ParentField: "ID"},
+			}},
+		}, models)
+	})
+
+	t.Run("labels for multiple foreign keys to the same parent", func(t *testing.T) {
+		t.Parallel()
+		models, err := gen.Infer(structs, map[string]string{"Member.InviterID": "User"})
+		require.NoError(t, err)
+		assert.Equal(t, []gen.ForeignKey{
+			{Field: "UserID", Type: "int64", Parent: "User", ParentField: "ID", Label: "user"},
+			{Field: "InviterID", Type: "sql.NullInt64", Parent: "User", ParentField: "ID", Label: "inviter", Optional: true},
+		}, models[2].ForeignKeys)
+	})
+
+	t.Run("invalid references", func(t *testing.T) {
+		t.Parallel()
+		for _, refs := range []map[string]string{
+			{"Member": "User"},
+			{"Unknown.UserID": "User"},
+			{"Member.UnknownID": "User"},
+			{"Member.OwnerID": "Unknown"},
+			{"Member.GroupID": "Group"},
+		} {
+			_, err := gen.Infer(structs, refs)
+			assert.Error(t, err, refs)
+		}
+	})
+}
+
+func TestGenerated(t *testing.T) {
+	t.Parallel()
+	var follower, followee, assignee *fixify.Model[model.User]
+	var follow *fixify.Model[model.Follow]
+	var ticket *fixify.Model[model.Ticket]
+	f := fixify.New(t,
+		fixture.Follow().Bind(&follow).
+			WithParentAs("follower", fixture.User().Bind(&follower)).
+			WithParentAs("followee", fixture.User().Bind(&followee)),
+		fixture.Ticket().Bind(&ticket).
+			WithParentAs("assignee", fixture.User().Bind(&assignee)),
+	)
+	var id int64
+	f.Apply(func(v any) error {
+		if u, ok := v.(*model.User); ok {
+			id++
+			u.ID = id
+		}
+		return nil
+	})
+	assert.Equal(t, follower.Value().ID, follow.Value().FollowerID)
+	assert.Equal(t, followee.Value().ID, follow.Value().FolloweeID)
+	if assert.NotNil(t, ticket.Value().AssigneeID) {
+		assert.Equal(t, assignee.Value().ID, *ticket.Value().AssigneeID)
+		assert.NotSame(t, &assignee.Value().ID, ticket.Value().AssigneeID)
+	}
+	assert.False(t, ticket.Value().ReviewerID.Valid)
+}
+
+func TestGenerated_strict(t *testing.T) {
+	t.Parallel()
+	// the ticket has no reviewer, which is optional.
+	f := fixify.Config{Strict: true}.New(t,
+		fixture.Ticket().WithParentAs("assignee", fixture.User()),
+	)
+	assert.Len(t, f.All(), 2)
+}
@@ -0,0 +1,160 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Model is a model for which a fixture constructor is generated.
+type Model struct {
+	Name        string
+	ForeignKeys []ForeignKey
+}
+
+// ForeignKey is a field of a model which refers to a field of its parent.
+type ForeignKey struct {
+	Field string
+	// Type is the type of Field, e.g. int64, *int64 or sql.NullInt64.
+	Type   string
+	Parent string
+	// ParentField is the field of the parent referred to by Field.
+	ParentField string
+	// Label is the label of the connector.
+	// The connector has no label if it is empty.
+	Label string
+	// Optional is true if Field may refer to no parent, e.g. *int64 or sql.NullInt64.
+	// The connector is wrapped with fixify.Optional.
+	Optional bool
+}
+
+// Infer infers the foreign keys of the structs from their fields.
+// A field named XxxID refers to the field ID of the struct Xxx.
+// refs maps a field in the form of Struct.Field to the name of the struct it refers to,
+// which is used for fields whose names do not follow the convention, e.g. Follow.FollowerID.
+// Fields which do not refer to any struct or whose types are not compatible with ID are ignored.
+// If a model has multiple foreign keys to the same parent, they are labeled after the fields, e.g. follower.
+func Infer(structs []Struct, refs map[string]string) ([]Model, error) {
+	byName := make(map[string]Struct, len(structs))
+	for _, s := range structs {
+		byName[s.Name] = s
+	}
+	for ref, parent := range refs {
+		name, field, ok := strings.Cut(ref, ".")
+		if !ok {
+			return nil, fmt.Errorf("invalid reference %q: want Struct.Field", ref)
+		}
+		s, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid reference %q: no struct %s", ref, name)
+		}
+		if _, ok := s.field(field); !ok {
+			return nil, fmt.Errorf("invalid reference %q: %s has no field %s", ref, name, field)
+		}
+		if _, ok := byName[parent]; !ok {
+			return nil, fmt.Errorf("invalid reference %q: no struct %s", ref, parent)
+		}
+	}
+	models := make([]Model, 0, len(structs))
+	for _, s := range structs {
+		m := Model{Name: s.Name}
+		for _, f := range s.Fields {
+			parentName, explicit := refs[s.Name+"."+f.Name]
+			if !explicit {
+				if f.Name == "ID" || !strings.HasSuffix(f.Name, "ID") {
+					continue
+				}
+				parentName = strings.TrimSuffix(f.Name, "ID")
+			}
+			parent, ok := byName[parentName]
+			if !ok {
+				continue
+			}
+			id, ok := parent.field("ID")
+			if !ok {
+				if explicit {
+					return nil, fmt.Errorf("invalid reference %s.%s: %s has no field ID", s.Name, f.Name, parent.Name)
+				}
+				continue
+			}
+			if !compatible(f.Type, id.Type) {
+				if explicit {
+					return nil, fmt.Errorf("invalid reference %s.%s: %s is not compatible with %s of %s.ID", s.Name, f.Name, f.Type, id.Type, parent.Name)
+				}
+				continue
+			}
+			m.ForeignKeys = append(m.ForeignKeys, ForeignKey{
+				Field:       f.Name,
+				Type:        f.Type,
+				Parent:      parent.Name,
+				ParentField: id.Name,
+				Optional:    nullable(f.Type),
+			})
+		}
+		count := make(map[string]int)
+		for _, fk := range m.ForeignKeys {
+			count[fk.Parent]++
+		}
+		for i, fk := range m.ForeignKeys {
+			if count[fk.Parent] > 1 {
+				m.ForeignKeys[i].Label = lowerFirst(strings.TrimSuffix(fk.Field, "ID"))
+			}
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// compatible reports whether a field of type fk can hold a value of type id.
+func compatible(fk, id string) bool {
+	if fk == id || fk == "*"+id {
+		return true
+	}
+	v, ok := nullValue(fk)
+	return ok && v.typ == id
+}
+
+type nullField struct {
+	// name is the name of the field holding the value.
+	name string
+	typ  string
+}
+
+// nullValue returns the field holding the value of a nullable type of database/sql.
+func nullValue(typ string) (nullField, bool) {
+	switch typ {
+	case "sql.NullInt64":
+		return nullField{"Int64", "int64"}, true
+	case "sql.NullInt32":
+		return nullField{"Int32", "int32"}, true
+	case "sql.NullInt16":
+		return nullField{"Int16", "int16"}, true
+	case "sql.NullByte":
+		return nullField{"Byte", "byte"}, true
+	case "sql.NullString":
+		return nullField{"String", "string"}, true
+	}
+	return nullField{}, false
+}
+
+// nullable reports whether the type of a foreign key can represent no parent.
+func nullable(typ string) bool {
+	_, ok := nullValue(typ)
+	return ok || strings.HasPrefix(typ, "*")
+}
+
+// lowerFirst lowers the leading upper case letters of s, e.g. Follower -> follower and URLPath -> urlPath.
+func lowerFirst(s string) string {
+	r := []rune(s)
+	for i := range r {
+		if !unicode.IsUpper(r[i]) {
+			break
+		}
+		// keep the upper case letter starting the next word.
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
@@ -0,0 +1,147 @@
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrNoGoFiles is returned by [ParseDir] if the directory contains no Go files.
+var ErrNoGoFiles = errors.New("no Go files")
+
+// Struct is a struct type declared in a model package.
+type Struct struct {
+	Name   string
+	Fields []Field
+	// File is the base name of the file declaring the struct.
+	File string
+	// Table is the name of the table if the struct is converted from a table by [FromTables].
+	Table string
+}
+
+// Field is a field of a struct.
+type Field struct {
+	Name string
+	// Type is the type of the field in the source code.
+	// Types of the package database/sql are qualified by sql, e.g. sql.NullInt64.
+	Type string
+}
+
+// field returns the field with the name.
+func (s Struct) field(name string) (Field, bool) {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// ParseDir parses the Go files in dir except for tests,
+// and returns the package name and the exported struct types in the declaration order.
+// Embedded and unexported fields are ignored.
+func ParseDir(dir string) (string, []Struct, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	fset := token.NewFileSet()
+	var pkg string
+	var structs []Struct
+	for _, name := range names {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+		if pkg != "" && pkg != file.Name.Name {
+			return "", nil, fmt.Errorf("multiple packages in %s: %s and %s", dir, pkg, file.Name.Name)
+		}
+		pkg = file.Name.Name
+		for _, s := range parseFile(file) {
+			s.File = name
+			structs = append(structs, s)
+		}
+	}
+	if pkg == "" {
+		return "", nil, fmt.Errorf("%w in %s", ErrNoGoFiles, dir)
+	}
+	return pkg, structs, nil
+}
+
+func parseFile(file *ast.File) []Struct {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+	var structs []Struct
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok || !ts.Name.IsExported() || ts.TypeParams != nil {
+				continue
+			}
+			s := Struct{Name: ts.Name.Name}
+			for _, f := range st.Fields.List {
+				typ := typeString(f.Type, imports)
+				for _, name := range f.Names {
+					if name.IsExported() {
+						s.Fields = append(s.Fields, Field{Name: name.Name, Type: typ})
+					}
+				}
+			}
+			structs = append(structs, s)
+		}
+	}
+	return structs
+}
+
+// typeString returns the type expression as a string.
+// Types of the package database/sql are qualified by sql regardless of the import name.
+func typeString(expr ast.Expr, imports map[string]string) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.StarExpr:
+		return "*" + typeString(e.X, imports)
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			path := imports[x.Name]
+			if path == "database/sql" {
+				return "sql." + e.Sel.Name
+			}
+			return path + "." + e.Sel.Name
+		}
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return "[]" + typeString(e.Elt, imports)
+		}
+	}
+	// the other types are never foreign keys.
+	return fmt.Sprintf("%T", expr)
+}
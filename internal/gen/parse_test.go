@@ -0,0 +1,73 @@
+package gen_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/qawatake/fixify/internal/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDir(t *testing.T) {
+	t.Parallel()
+	t.Run("structs", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		write(t, dir, "a.go", `package m
+
+import db "database/sql"
+
+type A struct {
+	ID       int64
+	BID, CID *int64
+	DID      db.NullInt64
+	hidden   int64
+	Embedded
+}
+
+type unexported struct{ ID int64 }
+
+type Generic[T any] struct{ ID T }
+
+type Embedded struct{}
+`)
+		write(t, dir, "a_test.go", `package m
+
+type T struct{}
+`)
+		pkg, structs, err := gen.ParseDir(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "m", pkg)
+		assert.Equal(t, []gen.Struct{
+			{Name: "A", Fields: []gen.Field{
+				{Name: "ID", Type: "int64"},
+				{Name: "BID", Type: "*int64"},
+				{Name: "CID", Type: "*int64"},
+				{Name: "DID", Type: "sql.NullInt64"},
+			}, File: "a.go"},
+			{Name: "Embedded", File: "a.go"},
+		}, structs)
+	})
+
+	t.Run("multiple packages", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		write(t, dir, "a.go", "package a\n")
+		write(t, dir, "b.go", "package b\n")
+		_, _, err := gen.ParseDir(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("no files", func(t *testing.T) {
+		t.Parallel()
+		_, _, err := gen.ParseDir(t.TempDir())
+		assert.ErrorIs(t, err, gen.ErrNoGoFiles)
+	})
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
@@ -0,0 +1,211 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// FromTables converts the tables to models with the foreign keys declared in the tables.
+// The table users is mapped to the struct User and the column follower_id to the field FollowerID.
+// existing is the structs already declared in the model package,
+// and the structs for the other tables are returned to be declared by [GenerateStructs].
+// If a model has multiple foreign keys to the same parent, they are labeled after the columns, e.g. follower_id.
+// Foreign keys of nullable columns are optional.
+// For existing structs, the types of the fields decide whether they are nullable.
+func FromTables(tables []Table, existing []Struct) ([]Struct, []Model, error) {
+	structs := make(map[string]Struct, len(tables))
+	for _, s := range existing {
+		structs[s.Name] = s
+	}
+	byTable := make(map[string]Table, len(tables))
+	var declared []Struct
+	for _, t := range tables {
+		byTable[t.Name] = t
+		name := structName(t.Name)
+		if _, ok := structs[name]; ok {
+			continue
+		}
+		s := Struct{Name: name, Table: t.Name}
+		for _, c := range t.Columns {
+			typ, err := goType(c)
+			if err != nil {
+				return nil, nil, fmt.Errorf("table %s: %w", t.Name, err)
+			}
+			s.Fields = append(s.Fields, Field{Name: fieldName(c.Name), Type: typ})
+		}
+		structs[name] = s
+		declared = append(declared, s)
+	}
+	models := make([]Model, 0, len(tables))
+	for _, t := range tables {
+		child := structs[structName(t.Name)]
+		m := Model{Name: child.Name}
+		count := make(map[string]int)
+		for _, ref := range t.References {
+			count[ref.Table]++
+		}
+		for _, ref := range t.References {
+			parentTable, ok := byTable[ref.Table]
+			if !ok {
+				return nil, nil, fmt.Errorf("table %s: unknown table %s referred to by %s", t.Name, ref.Table, strings.Join(ref.Columns, ", "))
+			}
+			refCols := ref.RefColumns
+			if len(refCols) == 0 {
+				refCols = parentTable.PrimaryKey
+			}
+			if len(ref.Columns) != 1 || len(refCols) != 1 {
+				return nil, nil, fmt.Errorf("table %s: composite foreign key (%s) is not supported", t.Name, strings.Join(ref.Columns, ", "))
+			}
+			parent := structs[structName(ref.Table)]
+			fk := ForeignKey{
+				Field:       fieldName(ref.Columns[0]),
+				Parent:      parent.Name,
+				ParentField: fieldName(refCols[0]),
+			}
+			field, ok := child.field(fk.Field)
+			if !ok {
+				return nil, nil, fmt.Errorf("table %s: %s has no field %s", t.Name, child.Name, fk.Field)
+			}
+			parentField, ok := parent.field(fk.ParentField)
+			if !ok {
+				return nil, nil, fmt.Errorf("table %s: %s has no field %s", t.Name, parent.Name, fk.ParentField)
+			}
+			if !compatible(field.Type, parentField.Type) {
+				return nil, nil, fmt.Errorf("table %s: %s.%s of %s is not compatible with %s.%s of %s", t.Name, child.Name, field.Name, field.Type, parent.Name, parentField.Name, parentField.Type)
+			}
+			fk.Type = field.Type
+			// a nullable column, or a nullable field of an existing struct, may refer to no parent.
+			fk.Optional = nullable(field.Type)
+			if count[ref.Table] > 1 {
+				fk.Label = ref.Columns[0]
+			}
+			m.ForeignKeys = append(m.ForeignKeys, fk)
+		}
+		models = append(models, m)
+	}
+	return declared, models, nil
+}
+
+// GenerateStructs returns the formatted source code of a file declaring the structs in the package.
+func GenerateStructs(pkg string, structs []Struct) ([]byte, error) {
+	imports := make(map[string]bool)
+	for _, s := range structs {
+		for _, f := range s.Fields {
+			if strings.HasPrefix(f.Type, "sql.") {
+				imports["database/sql"] = true
+			}
+			if strings.Contains(f.Type, "time.") {
+				imports["time"] = true
+			}
+		}
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by fixifygen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n", pkg)
+	if len(imports) > 0 {
+		b.WriteString("\nimport (\n")
+		for _, path := range []string{"database/sql", "time"} {
+			if imports[path] {
+				fmt.Fprintf(&b, "%q\n", path)
+			}
+		}
+		b.WriteString(")\n")
+	}
+	for _, s := range structs {
+		if s.Table != "" {
+			fmt.Fprintf(&b, "\n// %s is a row of the table %s.", s.Name, s.Table)
+		}
+		fmt.Fprintf(&b, "\ntype %s struct {\n", s.Name)
+		for _, f := range s.Fields {
+			fmt.Fprintf(&b, "%s %s\n", f.Name, f.Type)
+		}
+		b.WriteString("}\n")
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated code: %w", err)
+	}
+	return src, nil
+}
+
+// goType returns the Go type of the column.
+// Nullable columns are mapped to the nullable types of database/sql.
+func goType(c Column) (string, error) {
+	var typ, null string
+	switch c.Type {
+	case "BIGINT", "INT8", "BIGSERIAL", "SERIAL8":
+		typ, null = "int64", "sql.NullInt64"
+	case "INT", "INTEGER", "INT4", "MEDIUMINT", "SERIAL", "SERIAL4":
+		typ, null = "int32", "sql.NullInt32"
+	case "SMALLINT", "INT2", "TINYINT", "SMALLSERIAL", "SERIAL2":
+		typ, null = "int16", "sql.NullInt16"
+	case "BOOL", "BOOLEAN":
+		typ, null = "bool", "sql.NullBool"
+	case "REAL", "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DECIMAL", "NUMERIC":
+		typ, null = "float64", "sql.NullFloat64"
+	case "CHAR", "CHARACTER", "VARCHAR", "TEXT", "TINYTEXT", "MEDIUMTEXT", "LONGTEXT", "UUID", "JSON", "JSONB", "ENUM":
+		typ, null = "string", "sql.NullString"
+	case "DATE", "TIME", "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+		typ, null = "time.Time", "sql.NullTime"
+	case "BLOB", "BYTEA", "BINARY", "VARBINARY":
+		typ, null = "[]byte", "[]byte"
+	default:
+		return "", fmt.Errorf("unsupported type %s of column %s", c.Type, c.Name)
+	}
+	if c.NotNull {
+		return typ, nil
+	}
+	return null, nil
+}
+
+// initialisms are the words written in upper case in Go identifiers.
+var initialisms = map[string]bool{
+	"ID": true, "URL": true, "URI": true, "UUID": true, "API": true,
+	"HTTP": true, "JSON": true, "SQL": true, "IP": true, "UID": true,
+}
+
+// fieldName converts a snake case column name to a field name, e.g. follower_id -> FollowerID.
+func fieldName(column string) string {
+	var b strings.Builder
+	for _, w := range strings.Split(column, "_") {
+		if w == "" {
+			continue
+		}
+		if upper := strings.ToUpper(w); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + strings.ToLower(w[1:]))
+	}
+	return b.String()
+}
+
+// irregulars maps the plural nouns which are not singularized by the suffixes.
+var irregulars = map[string]string{
+	"statuses": "status",
+	"aliases":  "alias",
+	"people":   "person",
+	"children": "child",
+	"men":      "man",
+	"women":    "woman",
+}
+
+// structName converts a plural snake case table name to a struct name, e.g. companies -> Company.
+func structName(table string) string {
+	words := strings.Split(table, "_")
+	last := strings.ToLower(words[len(words)-1])
+	switch {
+	case irregulars[last] != "":
+		last = irregulars[last]
+	case strings.HasSuffix(last, "ies"):
+		last = strings.TrimSuffix(last, "ies") + "y"
+	case strings.HasSuffix(last, "sses"), strings.HasSuffix(last, "xes"), strings.HasSuffix(last, "ches"), strings.HasSuffix(last, "shes"):
+		last = strings.TrimSuffix(last, "es")
+	case strings.HasSuffix(last, "s") && !strings.HasSuffix(last, "ss"):
+		last = strings.TrimSuffix(last, "s")
+	}
+	words[len(words)-1] = last
+	return fieldName(strings.Join(words, "_"))
+}
@@ -0,0 +1,171 @@
+package gen_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/schema/fixture"
+	"github.com/qawatake/fixify/internal/example/schema/model"
+	"github.com/qawatake/fixify/internal/gen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromTables(t *testing.T) {
+	t.Parallel()
+	t.Run("up to date", func(t *testing.T) {
+		t.Parallel()
+		src, err := os.ReadFile("../example/schema/schema.sql")
+		require.NoError(t, err)
+		tables, err := gen.ParseDDL(string(src), nil)
+		require.NoError(t, err)
+		pkg, structs, err := gen.ParseDir("../example/schema/model")
+		require.NoError(t, err)
+		var existing []gen.Struct
+		for _, s := range structs {
+			if s.File != "model_gen.go" {
+				existing = append(existing, s)
+			}
+		}
+		declared, models, err := gen.FromTables(tables, existing)
+		require.NoError(t, err)
+
+		gotStructs, err := gen.GenerateStructs(pkg, declared)
+		require.NoError(t, err)
+		wantStructs, err := os.ReadFile("../example/schema/model/model_gen.go")
+		require.NoError(t, err)
+		assert.Equal(t, string(wantStructs), string(gotStructs), "run go generate ./internal/example/schema/fixture")
+
+		got, err := gen.Generate(gen.File{
+			Package:      "fixture",
+			ModelImport:  "github.com/qawatake/fixify/internal/example/schema/model",
+			ModelPackage: pkg,
+			Models:       models,
+		})
+		require.NoError(t, err)
+		want, err := os.ReadFile("../example/schema/fixture/fixture_gen.go")
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "run go generate ./internal/example/schema/fixture")
+	})
+
+	t.Run("types", func(t *testing.T) {
+		t.Parallel()
+		tables, err := gen.ParseDDL(`CREATE TABLE order_statuses (
+  id INT PRIMARY KEY,
+  api_url TEXT,
+  active BOOLEAN NOT NULL,
+  price NUMERIC(10, 2),
+  closed_at TIMESTAMP,
+  payload BYTEA
+);`, nil)
+		require.NoError(t, err)
+		declared, models, err := gen.FromTables(tables, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []gen.Struct{{Name: "OrderStatus", Table: "order_statuses", Fields: []gen.Field{
+			{Name: "ID", Type: "int32"},
+			{Name: "APIURL", Type: "sql.NullString"},
+			{Name: "Active", Type: "bool"},
+			{Name: "Price", Type: "sql.NullFloat64"},
+			{Name: "ClosedAt", Type: "sql.NullTime"},
+			{Name: "Payload", Type: "[]byte"},
+		}}}, declared)
+		assert.Equal(t, []gen.Model{{Name: "OrderStatus"}}, models)
+	})
+
+	t.Run("existing structs", func(t *testing.T) {
+		t.Parallel()
+		tables, err := gen.ParseDDL(`
+CREATE TABLE users (id BIGINT PRIMARY KEY);
+CREATE TABLE posts (id BIGINT PRIMARY KEY, user_id BIGINT REFERENCES users);
+`, nil)
+		require.NoError(t, err)
+		existing := []gen.Struct{{Name: "Post", Fields: []gen.Field{
+			{Name: "ID", Type: "int64"},
+			{Name: "UserID", Type: "*int64"},
+		}}}
+		declared, models, err := gen.FromTables(tables, existing)
+		require.NoError(t, err)
+		assert.Equal(t, []gen.Struct{{Name: "User", Table: "users", Fields: []gen.Field{{Name: "ID", Type: "int64"}}}}, declared)
+		assert.Equal(t, []gen.ForeignKey{
+			{Field: "UserID", Type: "*int64", Parent: "User", ParentField: "ID", Optional: true},
+		}, models[1].ForeignKeys)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		for name, tc := range map[string]struct {
+			src      string
+			existing []gen.Struct
+		}{
+			"unsupported type": {src: "CREATE TABLE users (id GEOMETRY);"},
+			"unknown table":    {src: "CREATE TABLE posts (id BIGINT, user_id BIGINT REFERENCES users (id));"},
+			"composite foreign key": {src: `
+CREATE TABLE users (id BIGINT, org_id BIGINT, PRIMARY KEY (id, org_id));
+CREATE TABLE posts (id BIGINT, user_id BIGINT, org_id BIGINT, FOREIGN KEY (user_id, org_id) REFERENCES users);`},
+			"incompatible types": {src: `
+CREATE TABLE users (id BIGINT PRIMARY KEY);
+CREATE TABLE posts (id BIGINT PRIMARY KEY, user_id TEXT REFERENCES users (id));`},
+			"missing field of existing struct": {
+				src: `
+CREATE TABLE users (id BIGINT PRIMARY KEY);
+CREATE TABLE posts (id BIGINT PRIMARY KEY, user_id BIGINT REFERENCES users (id));`,
+				existing: []gen.Struct{{Name: "Post", Fields: []gen.Field{{Name: "ID", Type: "int64"}}}},
+			},
+		} {
+			tables, err := gen.ParseDDL(tc.src, nil)
+			require.NoError(t, err, name)
+			_, _, err = gen.FromTables(tables, tc.existing)
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestGeneratedFromSchema(t *testing.T) {
+	t.Parallel()
+	var follower, followee *fixify.Model[model.User]
+	var follow *fixify.Model[model.Follow]
+	var mentor, mentee *fixify.Model[model.Employee]
+	department := fixture.Department().With(
+		fixture.Employee().Bind(&mentor).With(
+			fixture.Employee().Bind(&mentee),
+		),
+	)
+	f := fixify.New(t,
+		fixture.Company().With(department),
+		fixture.User().With(mentor),
+		fixture.User().With(mentee),
+		fixture.Follow().Bind(&follow).
+			WithParentAs("follower_id", fixture.User().Bind(&follower)).
+			WithParentAs("followee_id", fixture.User().Bind(&followee)),
+	)
+	var id int64
+	f.Apply(func(v any) error {
+		id++
+		switch v := v.(type) {
+		case *model.User:
+			v.ID = id
+		case *model.Employee:
+			v.ID = id
+		}
+		return nil
+	})
+	assert.Equal(t, follower.Value().ID, follow.Value().FollowerID)
+	assert.Equal(t, followee.Value().ID, follow.Value().FolloweeID)
+	assert.Equal(t, mentor.Value().ID, mentee.Value().MentorID.Int64)
+	assert.True(t, mentee.Value().MentorID.Valid)
+	assert.False(t, mentor.Value().MentorID.Valid)
+}
+
+func TestGeneratedFromSchema_strict(t *testing.T) {
+	t.Parallel()
+	// the employee has no mentor, which is optional.
+	f := fixify.Config{Strict: true}.New(t,
+		fixture.Company().With(
+			fixture.Department().With(
+				fixture.Employee().WithParent(fixture.User()),
+			),
+		),
+	)
+	assert.Len(t, f.All(), 4)
+}
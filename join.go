@@ -0,0 +1,29 @@
+package fixify
+
+// Join creates a join model for each pair of as and bs and connects it to both models of the pair.
+// newJoin receives the underlying models of the pair and may return nil to skip the pair.
+// The returned models can be passed to [New] directly.
+func Join[J, A, B any](as []*Model[A], bs []*Model[B], newJoin func(a *A, b *B) *Model[J]) []IModel {
+	return joinAs(nil, nil, as, bs, newJoin, caller(1))
+}
+
+// JoinAs is like [Join] but connects each join model to the models of the pair with labelA and labelB respectively.
+// It is useful when both parents have the same type.
+func JoinAs[J, A, B any](labelA, labelB any, as []*Model[A], bs []*Model[B], newJoin func(a *A, b *B) *Model[J]) []IModel {
+	return joinAs(labelA, labelB, as, bs, newJoin, caller(1))
+}
+
+// joinAs implements JoinAs. loc is the location where Join or JoinAs is called.
+func joinAs[J, A, B any](labelA, labelB any, as []*Model[A], bs []*Model[B], newJoin func(a *A, b *B) *Model[J], loc location) []IModel {
+	joins := make([]IModel, 0, len(as)*len(bs))
+	for _, a := range as {
+		for _, b := range bs {
+			j := newJoin(a.Value(), b.Value())
+			if j == nil {
+				continue
+			}
+			joins = append(joins, j.withParentAs(labelA, a, loc).withParentAs(labelB, b, loc))
+		}
+	}
+	return joins
+}
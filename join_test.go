@@ -0,0 +1,130 @@
+package fixify_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleJoin() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	students := []*fixify.Model[model.Student]{Student(), Student()}
+	classrooms := []*fixify.Model[model.Classroom]{Classroom(), Classroom(), Classroom()}
+	f := fixify.New(t,
+		// every student enrolls in every classroom.
+		fixify.Join(students, classrooms, func(_ *model.Student, _ *model.Classroom) *fixify.Model[model.Enrollment] {
+			return Enrollment()
+		})...,
+	)
+	fmt.Println("Number of enrollments:", len(filter[*model.Enrollment](f.All())))
+	// Output:
+	// Number of enrollments: 6
+}
+
+func ExampleJoinAs() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	users := []*fixify.Model[model.User]{User("alice"), User("bob"), User("carol")}
+	f := fixify.New(t,
+		// every user follows every other user.
+		fixify.JoinAs("follower", "followee", users, users, func(follower, followee *model.User) *fixify.Model[model.Follow] {
+			if follower == followee {
+				return nil
+			}
+			return Follow()
+		})...,
+	)
+	fmt.Println("Number of follows:", len(filter[*model.Follow](f.All())))
+	// Output:
+	// Number of follows: 6
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+	students := []*fixify.Model[model.Student]{Student(), Student()}
+	classrooms := []*fixify.Model[model.Classroom]{Classroom(), Classroom()}
+	f := fixify.New(t,
+		fixify.Join(students, classrooms, func(_ *model.Student, _ *model.Classroom) *fixify.Model[model.Enrollment] {
+			return Enrollment()
+		})...,
+	)
+	var id int64
+	f.Apply(func(v any) error {
+		id++
+		switch v := v.(type) {
+		case *model.Student:
+			v.ID = id
+		case *model.Classroom:
+			v.ID = id
+		case *model.Enrollment:
+			v.ID = id
+		}
+		return nil
+	})
+	assert.Len(t, f.All(), 8)
+	got := make([][2]int64, 0, 4)
+	for _, e := range filter[*model.Enrollment](f.All()) {
+		got = append(got, [2]int64{e.StudentID, e.ClassroomID})
+	}
+	want := make([][2]int64, 0, 4)
+	for _, s := range students {
+		for _, c := range classrooms {
+			want = append(want, [2]int64{s.Value().ID, c.Value().ID})
+		}
+	}
+	assert.ElementsMatch(t, want, got)
+}
+
+func TestJoinAs(t *testing.T) {
+	t.Parallel()
+	t.Run("predicate", func(t *testing.T) {
+		t.Parallel()
+		users := []*fixify.Model[model.User]{User("alice"), User("bob")}
+		f := fixify.New(t,
+			fixify.JoinAs("follower", "followee", users, users, func(follower, followee *model.User) *fixify.Model[model.Follow] {
+				if follower.Name != "alice" || followee.Name != "bob" {
+					return nil
+				}
+				return Follow()
+			})...,
+		)
+		f.Apply(func(v any) error {
+			switch v := v.(type) {
+			case *model.User:
+				if v.Name == "alice" {
+					v.ID = 1
+				} else {
+					v.ID = 2
+				}
+			case *model.Follow:
+				v.ID = 3
+			}
+			return nil
+		})
+		got := filter[*model.Follow](f.All())
+		assert.Equal(t, []*model.Follow{{ID: 3, FollowerID: 1, FolloweeID: 2}}, got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		users := []*fixify.Model[model.User]{User("alice")}
+		joins := fixify.JoinAs("follower", "followee", users, nil, func(_, _ *model.User) *fixify.Model[model.Follow] {
+			return Follow()
+		})
+		assert.Empty(t, joins)
+	})
+
+	t.Run("unknown label", func(t *testing.T) {
+		t.Parallel()
+		users := []*fixify.Model[model.User]{User("alice")}
+		assertInvalidModels(t, "cannot connect: child *model.Follow -> parent *model.User with label \"unknown\" (accepted: \"follower\", \"followee\")",
+			fixify.JoinAs("unknown", "followee", users, users, func(_, _ *model.User) *fixify.Model[model.Follow] {
+				return Follow()
+			})...,
+		)
+	})
+}
@@ -0,0 +1,63 @@
+package fixify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Label is a pair of a parent type and a label which a model accepts.
+type Label struct {
+	// ParentType is the pointer type of the parent model.
+	ParentType reflect.Type
+	// Label is the label given to [Model.WithParentAs]. nil represents no label.
+	Label any
+}
+
+// Labels returns every pair of a parent type and a label which m accepts as a direct parent,
+// in the order of the connectors given to [NewModel].
+func Labels[U any](m *Model[U]) []Label {
+	keys := m.acceptedKeys()
+	labels := make([]Label, 0, len(keys))
+	for _, key := range keys {
+		labels = append(labels, Label{ParentType: key.typ, Label: key.label})
+	}
+	return labels
+}
+
+// cannotConnectError returns an error for a child which does not accept the parent with the label.
+// If the child accepts the parent type with other labels, they are listed in the error.
+func cannotConnectError(child IModel, parent any, label any) error {
+	var accepted []string
+	for _, key := range child.acceptedKeys() {
+		if key.typ != reflect.TypeOf(parent) {
+			continue
+		}
+		if key.label == nil {
+			accepted = append(accepted, "no label")
+		} else {
+			accepted = append(accepted, fmt.Sprintf("%#v", key.label))
+		}
+	}
+	if len(accepted) == 0 {
+		return fmt.Errorf("cannot connect: child %T -> parent %T", child.model(), parent)
+	}
+	given := "no label"
+	if label != nil {
+		given = fmt.Sprintf("label %#v", label)
+	}
+	return fmt.Errorf("cannot connect: child %T -> parent %T with %s (accepted: %s)", child.model(), parent, given, strings.Join(accepted, ", "))
+}
+
+// describeKeys returns a description of the parents identified by keys.
+func describeKeys(keys []parentKey) string {
+	descs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key.label != nil {
+			descs = append(descs, fmt.Sprintf("parent %s with label %#v", key.typ, key.label))
+		} else {
+			descs = append(descs, fmt.Sprintf("parent %s", key.typ))
+		}
+	}
+	return strings.Join(descs, " and ")
+}
@@ -0,0 +1,79 @@
+package fixify_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleLabels() {
+	for _, l := range fixify.Labels(Follow()) {
+		fmt.Println(l.ParentType, l.Label)
+	}
+	// Output:
+	// *model.User follower
+	// *model.User followee
+}
+
+func TestLabels(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		labels []fixify.Label
+		want   []fixify.Label
+	}{
+		"no connectors": {
+			labels: fixify.Labels(Library()),
+			want:   []fixify.Label{},
+		},
+		"no label": {
+			labels: fixify.Labels(Enrollment()),
+			want: []fixify.Label{
+				{ParentType: reflect.TypeFor[*model.Student]()},
+				{ParentType: reflect.TypeFor[*model.Classroom]()},
+			},
+		},
+		"ancestor connector is excluded": {
+			labels: fixify.Labels(CompanyEmployee()),
+			want: []fixify.Label{
+				{ParentType: reflect.TypeFor[*model.Department]()},
+			},
+		},
+		"forward and backward connectors share a parent": {
+			labels: fixify.Labels(Manager()),
+			want: []fixify.Label{
+				{ParentType: reflect.TypeFor[*model.Department]()},
+			},
+		},
+		"multi-parent connector": {
+			labels: fixify.Labels(CompositeEnrollment()),
+			want: []fixify.Label{
+				{ParentType: reflect.TypeFor[*model.Student]()},
+				{ParentType: reflect.TypeFor[*model.Classroom]()},
+			},
+		},
+		"labels": {
+			labels: fixify.Labels(Ticket()),
+			want: []fixify.Label{
+				{ParentType: reflect.TypeFor[*model.User](), Label: "assignee"},
+				{ParentType: reflect.TypeFor[*model.User](), Label: "reviewer"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.labels)
+		})
+	}
+}
+
+func TestModel_With_accepted_labels(t *testing.T) {
+	t.Parallel()
+	assertInvalidModels(t, `cannot connect: child *model.Follow -> parent *model.User with no label (accepted: "follower", "followee")`,
+		User("alice").With(Follow()),
+	)
+}
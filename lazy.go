@@ -0,0 +1,150 @@
+package fixify
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// lazyModel is a reference to a model which is resolved in New.
+// Once resolved, it delegates every method to the resolved model.
+type lazyModel struct {
+	resolved IModel
+	f        func() IModel
+	// eager is true for the models returned by Adapt, which are resolved as soon as they are used.
+	eager bool
+}
+
+var _ IModel = &lazyModel{}
+
+// lazyEdge is a parent registered with a label before it is resolved.
+type lazyEdge struct {
+	label  any
+	parent *lazyModel
+	// loc is the location where the parent is registered.
+	loc location
+}
+
+// Lazy returns a reference to the model returned by f.
+// f is not called until [New], so the reference can point to a model declared later in the same [New] call.
+// Pass it to [Model.WithParent] or [Model.WithParentAs].
+func Lazy(f func() IModel) IModel {
+	return &lazyModel{f: f}
+}
+
+// resolve calls f only once and returns the model it refers to.
+func (l *lazyModel) resolve() (IModel, error) {
+	if l.resolved != nil {
+		return l.resolved, nil
+	}
+	m := l.f()
+	if ll, ok := m.(*lazyModel); ok {
+		var err error
+		if m, err = ll.resolve(); err != nil {
+			return nil, err
+		}
+	}
+	if isNil(m) {
+		if l.eager {
+			return nil, errors.New("adapted model is resolved to nil")
+		}
+		return nil, errors.New("lazy model is resolved to nil")
+	}
+	l.resolved = m
+	return m, nil
+}
+
+// target returns the resolved model.
+// It panics if the lazy model is used before it is resolved.
+func (l *lazyModel) target() IModel {
+	if l.resolved == nil {
+		panic(errors.New("lazy model is used before it is resolved in New"))
+	}
+	return l.resolved
+}
+
+func (l *lazyModel) model() any {
+	return l.target().model()
+}
+
+func (l *lazyModel) setParent(parent IModel) {
+	l.target().setParent(parent)
+}
+
+func (l *lazyModel) parents() []IModel {
+	return l.target().parents()
+}
+
+func (l *lazyModel) setChild(child IModel, label any) {
+	l.target().setChild(child, label)
+}
+
+func (l *lazyModel) hasChild(child IModel) bool {
+	return l.target().hasChild(child)
+}
+
+func (l *lazyModel) children() []IModel {
+	return l.target().children()
+}
+
+func (l *lazyModel) labels(child IModel) []any {
+	return l.target().labels(child)
+}
+
+func (l *lazyModel) canConnect(parent any, label any) bool {
+	return l.target().canConnect(parent, label)
+}
+
+func (l *lazyModel) connectors(parent any, label any) []func(t testing.TB) error {
+	return l.target().connectors(parent, label)
+}
+
+func (l *lazyModel) updateParents(tb testing.TB, hook connectHook) ([]IModel, error) {
+	tb.Helper()
+	return l.target().updateParents(tb, hook)
+}
+
+func (l *lazyModel) connectBeforeVisit(tb testing.TB, hook connectHook) error {
+	tb.Helper()
+	return l.target().connectBeforeVisit(tb, hook)
+}
+
+func (l *lazyModel) ambiguousAncestors() []error {
+	return l.target().ambiguousAncestors()
+}
+
+func (l *lazyModel) acceptedKeys() []parentKey {
+	return l.target().acceptedKeys()
+}
+
+func (l *lazyModel) resolveLazy() {
+	l.target().resolveLazy()
+}
+
+func (l *lazyModel) constructionErrors() []error {
+	return l.target().constructionErrors()
+}
+
+func (l *lazyModel) addConstructionError(err error) {
+	l.target().addConstructionError(err)
+}
+
+func (l *lazyModel) warnings() []error {
+	return l.target().warnings()
+}
+
+func (l *lazyModel) missingParents(strict bool) []error {
+	return l.target().missingParents(strict)
+}
+
+func isNil(m IModel) bool {
+	if m == nil {
+		return true
+	}
+	v := reflect.ValueOf(m)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+func (l *lazyModel) createParents(factories map[reflect.Type]Factory, chain []reflect.Type) ([]IModel, error) {
+	return l.target().createParents(factories, chain)
+}
@@ -0,0 +1,102 @@
+package fixify_test
+
+import (
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleLazy() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	var classroom *fixify.Model[model.Classroom]
+	fixify.New(t,
+		Student().With(
+			// classroom is declared later, so refer to it lazily.
+			Enrollment().WithParent(fixify.Lazy(func() fixify.IModel { return classroom })),
+		),
+		Classroom().Bind(&classroom),
+	)
+	// Output:
+}
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+	setter := func(v any) error {
+		switch v := v.(type) {
+		case *model.Student:
+			v.ID = 1
+		case *model.Classroom:
+			v.ID = 2
+		case *model.Enrollment:
+			v.ID = 3
+		}
+		return nil
+	}
+
+	t.Run("declared later", func(t *testing.T) {
+		t.Parallel()
+		var classroom *fixify.Model[model.Classroom]
+		f := fixify.New(t,
+			Student().With(
+				Enrollment().WithParent(fixify.Lazy(func() fixify.IModel { return classroom })),
+			),
+			Classroom().Bind(&classroom),
+		)
+		f.Apply(setter)
+		assert.Len(t, f.All(), 3)
+		got := filter[*model.Enrollment](f.All())
+		assert.Equal(t, []*model.Enrollment{{ID: 3, StudentID: 1, ClassroomID: 2}}, got)
+	})
+
+	t.Run("not passed to New", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.New(t,
+			Student().With(
+				Enrollment().WithParent(fixify.Lazy(func() fixify.IModel { return Classroom() })),
+			),
+		)
+		f.Apply(setter)
+		assert.Len(t, f.All(), 3)
+		got := filter[*model.Enrollment](f.All())
+		assert.Equal(t, []*model.Enrollment{{ID: 3, StudentID: 1, ClassroomID: 2}}, got)
+	})
+
+	t.Run("passed to New", func(t *testing.T) {
+		t.Parallel()
+		library := Library()
+		f := fixify.New(t, fixify.Lazy(func() fixify.IModel { return library }), library)
+		assert.Len(t, f.All(), 1)
+	})
+
+	t.Run("resolved to nil", func(t *testing.T) {
+		t.Parallel()
+		var classroom *fixify.Model[model.Classroom]
+		assertInvalidModels(t, "lazy model is resolved to nil",
+			Enrollment().WithParent(fixify.Lazy(func() fixify.IModel { return classroom })),
+		)
+	})
+
+	t.Run("passed to New and resolved to nil", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.New(dt, fixify.Lazy(func() fixify.IModel { return nil }))
+		assert.Equal(t, []string{"invalid models: lazy model is resolved to nil"}, dt.messages)
+	})
+
+	t.Run("try to connect to non-parent", func(t *testing.T) {
+		t.Parallel()
+		assertInvalidModels(t, "cannot connect: child *model.Enrollment -> parent *model.Library",
+			Enrollment().WithParent(fixify.Lazy(func() fixify.IModel { return Library() })),
+		)
+	})
+
+	t.Run("lazy child", func(t *testing.T) {
+		t.Parallel()
+		assertInvalidModels(t, "lazy model cannot be a child of *model.Library: use WithParent instead",
+			Library().With(fixify.Lazy(func() fixify.IModel { return Book() })),
+		)
+	})
+}
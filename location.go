@@ -0,0 +1,32 @@
+package fixify
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+)
+
+// location is a location in the source code.
+type location struct {
+	file string
+	line int
+}
+
+// caller returns the location of the caller.
+// The argument skip is the number of stack frames to ascend, with 0 identifying the caller of caller.
+func caller(skip int) location {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return location{}
+	}
+	return location{file: filepath.Base(file), line: line}
+}
+
+// wrap annotates err with the location.
+// It returns err as is if the location is unknown.
+func (l location) wrap(err error) error {
+	if l.file == "" {
+		return err
+	}
+	return fmt.Errorf("%s:%d: %w", l.file, l.line, err)
+}
@@ -0,0 +1,102 @@
+package fixify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// connectParentsFunc2[U, A, B] implements Connecter[U].
+type connectParentsFunc2[U, A, B any] struct {
+	keyA parentKey
+	keyB parentKey
+	fn   func(t testing.TB, childModel *U, parentA *A, parentB *B)
+}
+
+var _ Connecter[int] = &connectParentsFunc2[int, string, bool]{}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f *connectParentsFunc2[U, A, B]) canConnect(parentModel any, label any) bool {
+	for _, key := range f.parentKeys() {
+		if reflect.TypeOf(parentModel) == key.typ && label == key.label {
+			return true
+		}
+	}
+	return false
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f *connectParentsFunc2[U, A, B]) connect(_ testing.TB, _ *U, _ any, _ any) error {
+	// the function is called with both parents in connectParents.
+	return nil
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f *connectParentsFunc2[U, A, B]) connectParents(tb testing.TB, childModel *U, parentModels []any) error {
+	tb.Helper()
+	a, okA := parentModels[0].(*A)
+	b, okB := parentModels[1].(*B)
+	if okA && okB {
+		f.fn(tb, childModel, a, b)
+	}
+	return nil
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f *connectParentsFunc2[U, A, B]) parentKeys() []parentKey {
+	return []parentKey{f.keyA, f.keyB}
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f *connectParentsFunc2[U, A, B]) requirement() requirement {
+	return requirementDefault
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[U].
+func (f *connectParentsFunc2[U, A, B]) kind() connectorKind {
+	return connectorKindMulti
+}
+
+// ConnectorFunc2 translates a function of the form func(t testing.TB, childModel *U, parentA *A, parentB *B) into Connecter[U].
+// Unlike [ConnectorFunc], the function is called only when the child model has both parents of type *A and *B,
+// after both of them are visited.
+// If the child model has multiple parents of the same type, the function is called for every combination.
+// It is useful to set composite keys or to check invariants between parents in one place.
+func ConnectorFunc2[U, A, B any](f func(t testing.TB, childModel *U, parentA *A, parentB *B)) Connecter[U] {
+	return ConnectorFunc2WithLabels[U, A, B, any, any](nil, nil, f)
+}
+
+// ConnectorFunc2WithLabels is like [ConnectorFunc2] but the parents are connected with labelA and labelB respectively.
+// See [ConnectorFuncWithLabel] for labels.
+func ConnectorFunc2WithLabels[U, A, B any, LA, LB comparable](labelA LA, labelB LB, f func(t testing.TB, childModel *U, parentA *A, parentB *B)) Connecter[U] {
+	return &connectParentsFunc2[U, A, B]{
+		keyA: parentKey{typ: reflect.TypeFor[*A](), label: labelA},
+		keyB: parentKey{typ: reflect.TypeFor[*B](), label: labelB},
+		fn:   f,
+	}
+}
+
+// combinations returns every combination of one element from each of candidates.
+func combinations(candidates [][]any) [][]any {
+	combs := [][]any{{}}
+	for _, cs := range candidates {
+		next := make([][]any, 0, len(combs)*len(cs))
+		for _, comb := range combs {
+			for _, c := range cs {
+				next = append(next, append(append(make([]any, 0, len(comb)+1), comb...), c))
+			}
+		}
+		combs = next
+	}
+	return combs
+}
+
+// typeNames returns the comma-separated type names of the models.
+func typeNames(models []any) string {
+	names := make([]string, 0, len(models))
+	for _, m := range models {
+		names = append(names, fmt.Sprintf("%T", m))
+	}
+	return strings.Join(names, ", ")
+}
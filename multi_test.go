@@ -0,0 +1,140 @@
+package fixify_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleConnectorFunc2() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	var classroom *fixify.Model[model.Classroom]
+	f := fixify.New(t,
+		Classroom().Bind(&classroom),
+		Student().With(
+			CompositeEnrollment().WithParent(classroom),
+		),
+	)
+	f.Apply(func(v any) error {
+		switch v := v.(type) {
+		case *model.Student:
+			v.ID = 1
+		case *model.Classroom:
+			v.ID = 2
+		}
+		return nil
+	})
+	for _, e := range filter[*model.Enrollment](f.All()) {
+		fmt.Printf("StudentID: %d ClassroomID: %d\n", e.StudentID, e.ClassroomID)
+	}
+	// Output:
+	// StudentID: 1 ClassroomID: 2
+}
+
+func TestConnectorFunc2(t *testing.T) {
+	t.Parallel()
+	t.Run("one of parents", func(t *testing.T) {
+		t.Parallel()
+		called := false
+		f := fixify.New(t,
+			Student().With(
+				fixify.NewModel(new(model.Enrollment),
+					fixify.ConnectorFunc2(func(_ testing.TB, _ *model.Enrollment, _ *model.Student, _ *model.Classroom) {
+						called = true
+					}),
+				),
+			),
+		)
+		f.Apply(func(_ any) error { return nil })
+		assert.False(t, called)
+	})
+
+	t.Run("parents are visited", func(t *testing.T) {
+		t.Parallel()
+		var classroom *fixify.Model[model.Classroom]
+		f := fixify.New(t,
+			Classroom().Bind(&classroom),
+			Student().With(
+				CompositeEnrollment().WithParent(classroom),
+			),
+		)
+		f.Apply(func(v any) error {
+			switch v := v.(type) {
+			case *model.Student:
+				v.ID = 1
+			case *model.Classroom:
+				v.ID = 2
+			case *model.Enrollment:
+				assert.Equal(t, &model.Enrollment{StudentID: 1, ClassroomID: 2}, v)
+			}
+			return nil
+		})
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.New(t,
+			fixify.NewModel(new(model.Follow),
+				fixify.ConnectorFunc2WithLabels("follower", "followee", func(_ testing.TB, follow *model.Follow, follower, followee *model.User) {
+					follow.FollowerID = follower.ID
+					follow.FolloweeID = followee.ID
+				}),
+			).
+				WithParentAs("follower", User("alice")).
+				WithParentAs("followee", User("bob")),
+		)
+		f.Apply(func(v any) error {
+			if v, ok := v.(*model.User); ok {
+				if v.Name == "alice" {
+					v.ID = 1
+				} else {
+					v.ID = 2
+				}
+			}
+			return nil
+		})
+		assert.Equal(t, []*model.Follow{{FollowerID: 1, FolloweeID: 2}}, filter[*model.Follow](f.All()))
+	})
+
+	t.Run("unknown label", func(t *testing.T) {
+		t.Parallel()
+		assertInvalidModels(t, "cannot connect: child *model.Follow -> parent *model.User with label \"unknown\" (accepted: \"follower\", \"followee\")",
+			fixify.NewModel(new(model.Follow),
+				fixify.ConnectorFunc2WithLabels("follower", "followee", func(_ testing.TB, _ *model.Follow, _, _ *model.User) {}),
+			).WithParentAs("unknown", User("alice")),
+		)
+	})
+
+	t.Run("missing parent in strict mode", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.Config{Strict: true}.New(dt,
+			Student().With(
+				CompositeEnrollment(),
+			),
+		)
+		assert.Equal(t, []string{"missing parents: *model.Enrollment has no parent of type *model.Classroom"}, dt.messages)
+	})
+
+	t.Run("factories", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.Config{Factories: []fixify.Factory{fixify.FactoryFunc(Student), fixify.FactoryFunc(Classroom)}}.New(t,
+			CompositeEnrollment(),
+		)
+		assert.Len(t, f.All(), 3)
+	})
+}
+
+// CompositeEnrollment represents a fixture for an enrollment connected to a student and a classroom at once.
+func CompositeEnrollment() *fixify.Model[model.Enrollment] {
+	return fixify.NewModel(new(model.Enrollment),
+		fixify.ConnectorFunc2(func(_ testing.TB, enrollment *model.Enrollment, student *model.Student, classroom *model.Classroom) {
+			enrollment.StudentID = student.ID
+			enrollment.ClassroomID = classroom.ID
+		}),
+	)
+}
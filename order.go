@@ -0,0 +1,250 @@
+package fixify
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// defaultMaxOrders is the number of orders run by [ForEachOrder] by default.
+const defaultMaxOrders = 100
+
+// OrderOption configures [ForEachOrder].
+type OrderOption func(*orderOptions)
+
+type orderOptions struct {
+	maxOrders int
+}
+
+// MaxOrders limits the number of orders run by [ForEachOrder] to n, which must be at least 1.
+func MaxOrders(n int) OrderOption {
+	return func(o *orderOptions) {
+		o.maxOrders = n
+	}
+}
+
+// ForEachOrder runs test as a subtest for each distinct topological order of the models returned by build,
+// so that a test relying on the order of models without dependencies between them fails deterministically.
+// The fixture passed to test is created by [New] with the models sorted in the order.
+// build is called for each order to create new models, and must return models connected in the same way every time.
+// If the models have more orders than [MaxOrders], which is 100 by default,
+// the declaration order described in [Config] and orders sampled randomly are run instead.
+func ForEachOrder(t *testing.T, build func() []IModel, test func(t *testing.T, f *Fixture), opts ...OrderOption) {
+	t.Helper()
+	o := orderOptions{maxOrders: defaultMaxOrders}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxOrders < 1 {
+		t.Fatalf("MaxOrders must be at least 1, but %d is given", o.maxOrders)
+		return
+	}
+	models := build()
+	all, _ := collect(models)
+	// New reports invalid models, which may have no topological order, before the orders are enumerated.
+	New(t, models...)
+	for i, order := range topologicalOrders(all, o.maxOrders) {
+		t.Run(fmt.Sprintf("order_%d", i), func(t *testing.T) {
+			models := build()
+			if collected, _ := collect(models); len(collected) != len(order) {
+				t.Fatalf("build returned %d models connected to each other, but %d models at first", len(collected), len(order))
+				return
+			}
+			f := &Fixture{
+				t:       t,
+				set:     map[IModel]struct{}{},
+				visited: map[IModel]struct{}{},
+				order: func(all []IModel) []IModel {
+					sorted := make([]IModel, 0, len(all))
+					for _, i := range order {
+						sorted = append(sorted, all[i])
+					}
+					return sorted
+				},
+			}
+			f.add(models)
+			// models added afterward are sorted randomly.
+			f.order = nil
+			test(t, f)
+		})
+	}
+}
+
+// topologicalOrders returns at most limit distinct topological orders of the models as indices of all.
+// If there are more orders than limit, it returns the declaration order followed by orders sampled randomly.
+func topologicalOrders(all []IModel, limit int) [][]int {
+	g := newGraph(all)
+	orders := g.enumerate(limit + 1)
+	if len(orders) <= limit {
+		return orders
+	}
+	orders = orders[:1]
+	seen := map[string]bool{fmt.Sprint(orders[0]): true}
+	// sampling may hit the same order repeatedly, so it gives up after enough attempts.
+	for attempts := 0; len(orders) < limit && attempts < 10*limit; attempts++ {
+		order := g.sample()
+		if key := fmt.Sprint(order); !seen[key] {
+			seen[key] = true
+			orders = append(orders, order)
+		}
+	}
+	return orders
+}
+
+// cyclicError returns an error if some models are left out of sorted,
+// which happens only if they are in a cycle or descendants of a cycle.
+// A cycle of a model and its direct parent is rejected in constructing models, but a longer one is not.
+func cyclicError(all, sorted []IModel) error {
+	if len(sorted) == len(all) {
+		return nil
+	}
+	set := make(map[IModel]struct{}, len(sorted))
+	for _, m := range sorted {
+		set[m] = struct{}{}
+	}
+	var left []any
+	for _, m := range all {
+		if _, ok := set[m]; !ok {
+			left = append(left, m.model())
+		}
+	}
+	return fmt.Errorf("cyclic dependency: cannot sort %s", typeNames(left))
+}
+
+// graph is the dependency graph of models represented by their indices.
+type graph struct {
+	children   [][]int
+	numParents []int
+}
+
+// newGraph returns the graph of the models.
+// Parents not in all are ignored.
+func newGraph(all []IModel) *graph {
+	index := make(map[IModel]int, len(all))
+	for i, m := range all {
+		index[m] = i
+	}
+	g := &graph{
+		children:   make([][]int, len(all)),
+		numParents: make([]int, len(all)),
+	}
+	for i, m := range all {
+		for _, c := range m.children() {
+			if j, ok := index[c]; ok {
+				g.children[i] = append(g.children[i], j)
+				g.numParents[j]++
+			}
+		}
+	}
+	return g
+}
+
+// enumerate returns at most limit topological orders in the lexicographic order,
+// so that the first one is the declaration order.
+func (g *graph) enumerate(limit int) [][]int {
+	numParents := slices.Clone(g.numParents)
+	order := make([]int, 0, len(numParents))
+	used := make([]bool, len(numParents))
+	var orders [][]int
+	var visit func()
+	visit = func() {
+		if len(order) == len(numParents) {
+			orders = append(orders, slices.Clone(order))
+			return
+		}
+		for i := range numParents {
+			if len(orders) >= limit {
+				return
+			}
+			if used[i] || numParents[i] > 0 {
+				continue
+			}
+			used[i] = true
+			order = append(order, i)
+			for _, c := range g.children[i] {
+				numParents[c]--
+			}
+			visit()
+			for _, c := range g.children[i] {
+				numParents[c]++
+			}
+			order = order[:len(order)-1]
+			used[i] = false
+		}
+	}
+	visit()
+	return orders
+}
+
+// sample returns a topological order chosen randomly.
+func (g *graph) sample() []int {
+	numParents := slices.Clone(g.numParents)
+	var ready []int
+	for i, n := range numParents {
+		if n == 0 {
+			ready = append(ready, i)
+		}
+	}
+	order := make([]int, 0, len(numParents))
+	for len(ready) > 0 {
+		k := rand.IntN(len(ready))
+		i := ready[k]
+		ready[k] = ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		order = append(order, i)
+		for _, c := range g.children[i] {
+			numParents[c]--
+			if numParents[c] == 0 {
+				ready = append(ready, c)
+			}
+		}
+	}
+	return order
+}
+
+// sortModelsInOrder sorts the models in a topological order stably,
+// so that a model comes before the models following it in all as long as its parents allow.
+// Models in or below a cycle are left out as in sortModels.
+func sortModelsInOrder(all []IModel) []IModel {
+	g := newGraph(all)
+	numParents := slices.Clone(g.numParents)
+	ready := make(indexHeap, 0, len(all))
+	for i, n := range numParents {
+		if n == 0 {
+			ready = append(ready, i)
+		}
+	}
+	heap.Init(&ready)
+	sorted := make([]IModel, 0, len(all))
+	for ready.Len() > 0 {
+		i := heap.Pop(&ready).(int)
+		sorted = append(sorted, all[i])
+		for _, c := range g.children[i] {
+			numParents[c]--
+			if numParents[c] == 0 {
+				heap.Push(&ready, c)
+			}
+		}
+	}
+	return sorted
+}
+
+// indexHeap is a min-heap of indices.
+type indexHeap []int
+
+func (h indexHeap) Len() int           { return len(h) }
+func (h indexHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h indexHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *indexHeap) Push(x any) {
+	*h = append(*h, x.(int))
+}
+
+func (h *indexHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
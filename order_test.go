@@ -0,0 +1,216 @@
+package fixify_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachOrder(t *testing.T) {
+	t.Parallel()
+	t.Run("all orders", func(t *testing.T) {
+		t.Parallel()
+		var orders []string
+		fixify.ForEachOrder(t, func() []fixify.IModel {
+			return []fixify.IModel{
+				Company().With(
+					Department("a").With(Employee()),
+					Department("b"),
+				),
+			}
+		}, func(t *testing.T, f *fixify.Fixture) {
+			orders = append(orders, modelNames(f.All()))
+		})
+		assert.Equal(t, []string{
+			"company a employee b",
+			"company a b employee",
+			"company b a employee",
+		}, orders)
+	})
+
+	t.Run("max orders", func(t *testing.T) {
+		t.Parallel()
+		var orders []string
+		fixify.ForEachOrder(t, func() []fixify.IModel {
+			return []fixify.IModel{
+				Company().With(
+					Department("a"),
+					Department("b"),
+					Department("c"),
+					Department("d"),
+					Department("e"),
+				),
+			}
+		}, func(t *testing.T, f *fixify.Fixture) {
+			orders = append(orders, modelNames(f.All()))
+		}, fixify.MaxOrders(10))
+		assert.Len(t, orders, 10)
+		// the declaration order comes first.
+		assert.Equal(t, "company a b c d e", orders[0])
+		seen := make(map[string]bool)
+		for _, o := range orders {
+			assert.False(t, seen[o], "duplicate order: %s", o)
+			seen[o] = true
+			assert.Regexp(t, "^company ", o)
+		}
+	})
+
+	t.Run("new models for each order", func(t *testing.T) {
+		t.Parallel()
+		var departments []*model.Department
+		fixify.ForEachOrder(t, func() []fixify.IModel {
+			return []fixify.IModel{
+				Company().With(
+					Department("a"),
+					Department("b"),
+				),
+			}
+		}, func(t *testing.T, f *fixify.Fixture) {
+			var id int64
+			f.Apply(func(v any) error {
+				id++
+				switch v := v.(type) {
+				case *model.Company:
+					v.ID = id
+				case *model.Department:
+					v.ID = id
+					departments = append(departments, v)
+				}
+				return nil
+			})
+			for _, d := range filter[*model.Department](f.All()) {
+				assert.Equal(t, int64(1), d.CompanyID)
+			}
+		})
+		assert.Len(t, departments, 4)
+	})
+}
+
+// modelNames returns the names of the models in the example of companies joined by spaces.
+func modelNames(models []any) string {
+	names := make([]string, 0, len(models))
+	for _, m := range models {
+		switch m := m.(type) {
+		case *model.Company:
+			names = append(names, "company")
+		case *model.Department:
+			names = append(names, m.Name)
+		case *model.Employee:
+			names = append(names, "employee")
+		default:
+			names = append(names, fmt.Sprintf("%T", m))
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+func TestNew_randomOrder(t *testing.T) {
+	t.Parallel()
+	seen := make(map[string]bool)
+	for range 100 {
+		f := fixify.New(t,
+			Company().With(
+				Department("a").With(Employee()),
+				Department("b"),
+			),
+		)
+		seen[modelNames(f.All())] = true
+	}
+	assert.Equal(t, map[string]bool{
+		"company a employee b": true,
+		"company a b employee": true,
+		"company b a employee": true,
+	}, seen)
+}
+
+func TestNew_cyclic(t *testing.T) {
+	t.Parallel()
+	t.Run("cycle of three models", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		a, b, c := Cyclic(), Cyclic(), Cyclic()
+		a.With(b)
+		b.With(c)
+		c.With(a)
+		f := fixify.New(dt, a)
+		assert.Empty(t, f.All())
+		assert.Equal(t, []string{"cyclic dependency: cannot sort *model.Cyclic, *model.Cyclic, *model.Cyclic"}, dt.messages)
+	})
+
+	t.Run("lazy self-parent", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		var c *fixify.Model[model.Cyclic]
+		f := fixify.New(dt,
+			Cyclic().Bind(&c).WithParent(fixify.Lazy(func() fixify.IModel { return c })),
+			Library(),
+		)
+		assert.Empty(t, f.All())
+		assert.Equal(t, []string{"cyclic dependency: cannot sort *model.Cyclic"}, dt.messages)
+	})
+}
+
+func BenchmarkNew(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		build func() []fixify.IModel
+	}{
+		{
+			// 10000 models without dependencies.
+			name: "flat",
+			build: func() []fixify.IModel {
+				models := make([]fixify.IModel, 0, 10000)
+				for range 10000 {
+					models = append(models, Company())
+				}
+				return models
+			},
+		},
+		{
+			// 1 company, 99 departments and 9900 employees.
+			name: "tree",
+			build: func() []fixify.IModel {
+				company := Company()
+				for range 99 {
+					d := Department("d")
+					for range 100 {
+						d.With(Employee())
+					}
+					company.With(d)
+				}
+				return []fixify.IModel{company}
+			},
+		},
+		{
+			// a chain of 10000 models, each of which is the parent of the next one.
+			name: "chain",
+			build: func() []fixify.IModel {
+				models := make([]fixify.IModel, 0, 10000)
+				parent := Cyclic()
+				models = append(models, parent)
+				for range 9999 {
+					child := Cyclic().WithParent(parent)
+					models = append(models, child)
+					parent = child
+				}
+				return models
+			},
+		},
+	}
+	for _, bm := range benchmarks {
+		for _, cfg := range []fixify.Config{{}, {Deterministic: true}} {
+			b.Run(fmt.Sprintf("%s/deterministic=%t", bm.name, cfg.Deterministic), func(b *testing.B) {
+				for range b.N {
+					b.StopTimer()
+					models := bm.build()
+					b.StartTimer()
+					cfg.New(b, models...)
+				}
+			})
+		}
+	}
+}
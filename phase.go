@@ -0,0 +1,85 @@
+package fixify
+
+// Phase is a visitor applied by [Fixture.ApplyPhases].
+type Phase struct {
+	// Name is used in error messages.
+	Name string
+	// Visit is called for each model.
+	Visit func(model any) error
+	// BeforeConnect makes Visit called before the connectors of the model are called.
+	// By default, Visit is called after the connectors are called.
+	BeforeConnect bool
+}
+
+// ApplyPhases applies the phases in a single traversal in the topological order of the models.
+// For each model, the phases with BeforeConnect run first in the given order,
+// then the connectors of the model are called,
+// and then the other phases run in the given order.
+// Backward connectors are called after all phases of the model.
+// The parents updated by backward connectors are visited again by the phases without BeforeConnect
+// after all models are visited.
+// Models visited by a previous Apply or ApplyPhases are skipped.
+func (f *Fixture) ApplyPhases(phases ...Phase) {
+	f.t.Helper()
+	var before, after []Phase
+	for _, p := range phases {
+		if p.BeforeConnect {
+			before = append(before, p)
+		} else {
+			after = append(after, p)
+		}
+	}
+	updated := make(map[IModel]struct{})
+	for _, c := range f.connectors {
+		if _, ok := f.visited[c]; ok {
+			continue
+		}
+		f.visit(c, before)
+		for _, p := range c.parents() {
+			for _, label := range p.labels(c) {
+				for _, connect := range c.connectors(p.model(), label) {
+					if err := f.callConnect(c.model(), p.model(), label, func() error {
+						return connect(f.t)
+					}); err != nil {
+						f.t.Fatalf("failed to connect: child %T -> parent %T: %v", c.model(), p.model(), err)
+					}
+				}
+			}
+		}
+		if err := c.connectBeforeVisit(f.t, f.callConnect); err != nil {
+			f.t.Fatalf("failed to connect: %v", err)
+		}
+		f.visit(c, after)
+		f.visited[c] = struct{}{}
+		parents, err := c.updateParents(f.t, f.callConnect)
+		if err != nil {
+			f.t.Fatalf("failed to update parent: %v", err)
+		}
+		for _, p := range parents {
+			updated[p] = struct{}{}
+		}
+	}
+	// visit the updated parents again in the topological order.
+	for _, c := range f.connectors {
+		if _, ok := updated[c]; !ok {
+			continue
+		}
+		f.visit(c, after)
+	}
+}
+
+// visit applies the phases to the model.
+// It stops at the first phase that fails.
+func (f *Fixture) visit(c IModel, phases []Phase) {
+	f.t.Helper()
+	for _, p := range phases {
+		if err := f.callVisit(c.model(), p.Visit); err != nil {
+			if p.Name != "" {
+				f.t.Fatalf("failed to visit %v in phase %s: %v", c.model(), p.Name, err)
+			} else {
+				f.t.Fatalf("failed to visit %v: %v", c.model(), err)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package fixify_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixture_ApplyPhases(t *testing.T) {
+	t.Parallel()
+	t.Run("order", func(t *testing.T) {
+		t.Parallel()
+		var events []string
+		connects := 0
+		book := fixify.NewModel(&model.Book{},
+			fixify.ConnectorFunc(func(_ testing.TB, book *model.Book, library *model.Library) {
+				connects++
+				events = append(events, "connect")
+				book.LibraryID = library.ID
+			}),
+		)
+		f := fixify.New(t, Library().With(book))
+		phase := func(name string, beforeConnect bool) fixify.Phase {
+			return fixify.Phase{
+				Name: name,
+				Visit: func(v any) error {
+					events = append(events, fmt.Sprintf("%s %T", name, v))
+					return nil
+				},
+				BeforeConnect: beforeConnect,
+			}
+		}
+		var id int64
+		f.ApplyPhases(
+			phase("validate", false),
+			phase("defaults", true),
+			fixify.Phase{
+				Name: "persist",
+				Visit: func(v any) error {
+					id++
+					switch v := v.(type) {
+					case *model.Library:
+						v.ID = id
+					case *model.Book:
+						v.ID = id
+						events = append(events, fmt.Sprintf("persist %T with library %d", v, v.LibraryID))
+					}
+					return nil
+				},
+			},
+		)
+		assert.Equal(t, 1, connects)
+		assert.Equal(t, []string{
+			"defaults *model.Library",
+			"validate *model.Library",
+			"defaults *model.Book",
+			"connect",
+			"validate *model.Book",
+			"persist *model.Book with library 1",
+		}, events)
+	})
+
+	t.Run("visited models are skipped", func(t *testing.T) {
+		t.Parallel()
+		f := fixify.New(t, Library().With(Book()))
+		count := 0
+		phase := fixify.Phase{Visit: func(any) error {
+			count++
+			return nil
+		}}
+		f.ApplyPhases(phase, phase)
+		assert.Equal(t, 4, count)
+		f.Apply(phase.Visit)
+		assert.Equal(t, 4, count)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		f := fixify.New(dt, Library().With(Book()))
+		count := 0
+		f.ApplyPhases(
+			fixify.Phase{Name: "validate", Visit: func(any) error {
+				return errors.New("invalid")
+			}},
+			fixify.Phase{Visit: func(any) error {
+				count++
+				return nil
+			}},
+		)
+		// the following phases are not applied to the model whose phase fails.
+		assert.Equal(t, 2, dt.countFatalf)
+		assert.Regexp(t, `^failed to visit .* in phase validate: invalid$`, dt.messages[0])
+		assert.Equal(t, 0, count)
+	})
+}
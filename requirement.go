@@ -0,0 +1,49 @@
+package fixify
+
+// requirement represents whether a connector needs a parent to connect to.
+type requirement int
+
+const (
+	// requirementDefault represents a connector which is neither required nor optional explicitly.
+	requirementDefault requirement = iota
+	// requirementRequired represents a connector which must have a parent.
+	requirementRequired
+	// requirementOptional represents a connector which may have no parent.
+	requirementOptional
+)
+
+// isRequired returns true if a connector with the requirement must have a parent.
+// In strict mode, connectors not marked as optional are also required.
+func (r requirement) isRequired(strict bool) bool {
+	if strict {
+		return r != requirementOptional
+	}
+	return r == requirementRequired
+}
+
+// requirementConnecter overrides the requirement of the underlying connector.
+type requirementConnecter[T any] struct {
+	Connecter[T]
+	req requirement
+}
+
+//nolint:unused // it is necessary to implement the interface Connecter[T].
+func (c requirementConnecter[T]) requirement() requirement {
+	return c.req
+}
+
+// Required marks the connector as required.
+// [New] fails if a model has no parent that the connector can connect to.
+func Required[T any](c Connecter[T]) Connecter[T] {
+	return requirementConnecter[T]{Connecter: c, req: requirementRequired}
+}
+
+// Optional marks the connector as optional.
+// If a model has no parent that the connector can connect to, the connector is never called.
+// It is useful for nullable foreign keys such as *int64 or sql.NullInt64, which stay null without the parent.
+// Note that a connector marked as neither [Required] nor Optional is not checked by default either,
+// so Optional only makes a difference in the strict mode, where such a connector is regarded as required,
+// and with [Config.Factories], which never create a parent for an optional connector.
+func Optional[T any](c Connecter[T]) Connecter[T] {
+	return requirementConnecter[T]{Connecter: c, req: requirementOptional}
+}
@@ -0,0 +1,124 @@
+package fixify_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/qawatake/fixify"
+	"github.com/qawatake/fixify/internal/example/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleOptional() {
+	// t is passed from the test function.
+	t := &testing.T{}
+	// the assignee and the reviewer of the ticket are optional.
+	fixify.New(t,
+		Ticket().WithParentAs("assignee", User("alice")),
+		Ticket(),
+	)
+	// Output:
+}
+
+func TestRequired(t *testing.T) {
+	t.Parallel()
+	t.Run("with parent", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.New(dt, RequiredEmployee().WithParent(Department("finance")))
+		assert.Equal(t, 0, dt.countFatalf)
+	})
+
+	t.Run("without parent", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.New(dt, RequiredEmployee())
+		assert.Equal(t, 1, dt.countFatalf)
+		assert.Equal(t, []string{"missing parents: *model.Employee has no parent of type *model.Department"}, dt.messages)
+	})
+
+	t.Run("without labeled parent", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.New(dt, RequiredFollow().WithParentAs("follower", User("alice")))
+		assert.Equal(t, 1, dt.countFatalf)
+		assert.Equal(t, []string{`missing parents: *model.Follow has no parent of type *model.User with label "followee"`}, dt.messages)
+	})
+
+	t.Run("default connectors are not checked", func(t *testing.T) {
+		t.Parallel()
+		dt := &dummyTestReporter{TB: t}
+		fixify.New(dt, Employee())
+		assert.Equal(t, 0, dt.countFatalf)
+	})
+}
+
+func TestOptional(t *testing.T) {
+	t.Parallel()
+	f := fixify.New(t,
+		Ticket().WithParentAs("assignee", User("alice")),
+		Ticket().WithParentAs("reviewer", User("bob")),
+		Ticket(),
+	)
+	f.Apply(func(v any) error {
+		switch v := v.(type) {
+		case *model.User:
+			if v.Name == "alice" {
+				v.ID = 1
+			} else {
+				v.ID = 2
+			}
+		case *model.Ticket:
+			v.ID = 3
+		}
+		return nil
+	})
+	assignee := int64(1)
+	got := filter[*model.Ticket](f.All())
+	assert.ElementsMatch(t, []*model.Ticket{
+		{ID: 3, AssigneeID: &assignee},
+		{ID: 3, ReviewerID: sql.NullInt64{Int64: 2, Valid: true}},
+		{ID: 3, AssigneeID: nil, ReviewerID: sql.NullInt64{Valid: false}},
+	}, got)
+
+	// the foreign key is a copy, so it does not follow later changes of the parent.
+	for _, u := range filter[*model.User](f.All()) {
+		u.ID = 100
+	}
+	assert.ElementsMatch(t, []*model.Ticket{
+		{ID: 3, AssigneeID: &assignee},
+		{ID: 3, ReviewerID: sql.NullInt64{Int64: 2, Valid: true}},
+		{ID: 3, AssigneeID: nil, ReviewerID: sql.NullInt64{Valid: false}},
+	}, filter[*model.Ticket](f.All()))
+}
+
+func Ticket() *fixify.Model[model.Ticket] {
+	return fixify.NewModel(new(model.Ticket),
+		fixify.Optional(fixify.ConnectorFuncWithLabel("assignee", func(_ testing.TB, ticket *model.Ticket, assignee *model.User) {
+			id := assignee.ID
+			ticket.AssigneeID = &id
+		})),
+		fixify.Optional(fixify.ConnectorFuncWithLabel("reviewer", func(_ testing.TB, ticket *model.Ticket, reviewer *model.User) {
+			ticket.ReviewerID = sql.NullInt64{Int64: reviewer.ID, Valid: true}
+		})),
+	)
+}
+
+func RequiredEmployee() *fixify.Model[model.Employee] {
+	return fixify.NewModel(new(model.Employee),
+		fixify.Required(fixify.ConnectorFunc(func(_ testing.TB, employee *model.Employee, department *model.Department) {
+			employee.DepartmentID = department.ID
+		})),
+	)
+}
+
+func RequiredFollow() *fixify.Model[model.Follow] {
+	return fixify.NewModel(new(model.Follow),
+		fixify.Required(fixify.ConnectorFuncWithLabel("follower", func(_ testing.TB, follow *model.Follow, follower *model.User) {
+			follow.FollowerID = follower.ID
+		})),
+		fixify.Required(fixify.ConnectorFuncWithLabel("followee", func(_ testing.TB, follow *model.Follow, followee *model.User) {
+			follow.FolloweeID = followee.ID
+		})),
+	)
+}